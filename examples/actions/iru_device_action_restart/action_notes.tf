@@ -0,0 +1,11 @@
+# Record a note on the device after every successful action, linking back to
+# the CI job that ran it.
+provider "iru" {
+  action_notes = {
+    run_id_env = "CI_JOB_URL"
+  }
+}
+
+action "iru_device_action_restart" "noted" {
+  device_id = "8a9f88d9-e7f4-47e6-9326-fd4b39534c4e"
+}
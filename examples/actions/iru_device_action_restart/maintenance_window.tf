@@ -0,0 +1,18 @@
+# Only allow disruptive actions on weekend nights, New York time.
+provider "iru" {
+  maintenance_window_action = "wait"
+
+  maintenance_windows = [
+    {
+      days      = ["friday", "saturday"]
+      start     = "22:00"
+      end       = "04:00"
+      time_zone = "America/New_York"
+    },
+  ]
+}
+
+action "iru_device_action_restart" "in_window" {
+  device_id                  = "8a9f88d9-e7f4-47e6-9326-fd4b39534c4e"
+  respect_maintenance_window = true
+}
@@ -4,6 +4,12 @@ data "iru_ade_devices" "by_blueprint" {
   blueprint_id = "your-blueprint-uuid"
 }
 
+data "iru_ade_devices" "unenrolled_macbooks" {
+  device_family        = "Mac"
+  serial_number_prefix = "C02"
+  is_enrolled          = false
+}
+
 output "all_ade_device_serials" {
   value = [for d in data.iru_ade_devices.all.devices : d.serial_number]
 }
@@ -0,0 +1,18 @@
+# Which versions of Chrome are installed, and on how many devices.
+data "iru_app_inventory" "chrome" {
+  bundle_ids = ["com.google.Chrome"]
+}
+
+output "chrome_versions" {
+  value = { for v in data.iru_app_inventory.chrome.versions : v.version => v.device_count }
+}
+
+# Devices running a Chrome release older than 120.
+data "iru_app_inventory" "outdated_chrome" {
+  bundle_ids  = ["com.google.Chrome"]
+  max_version = "120"
+}
+
+output "outdated_chrome_devices" {
+  value = flatten([for v in data.iru_app_inventory.outdated_chrome.versions : v.devices[*].device_name])
+}
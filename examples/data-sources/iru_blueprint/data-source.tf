@@ -5,3 +5,13 @@ data "iru_blueprint" "example" {
 output "blueprint_name" {
   value = data.iru_blueprint.example.name
 }
+
+# Look up a blueprint by its exact name instead of its ID.
+data "iru_blueprint" "engineering" {
+  name = "Engineering"
+}
+
+# Or by a regular expression that must match exactly one blueprint.
+data "iru_blueprint" "staging" {
+  name_regex = "^Staging - "
+}
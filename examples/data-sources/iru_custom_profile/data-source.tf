@@ -0,0 +1,7 @@
+data "iru_custom_profile" "example" {
+  name = "Wi-Fi - Corporate"
+}
+
+output "profile_identifier" {
+  value = data.iru_custom_profile.example.mdm_identifier
+}
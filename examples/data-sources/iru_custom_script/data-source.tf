@@ -0,0 +1,7 @@
+data "iru_custom_script" "example" {
+  name_regex = "^Install Rosetta"
+}
+
+output "script_frequency" {
+  value = data.iru_custom_script.example.execution_frequency
+}
@@ -0,0 +1,13 @@
+# Wait for a Mac assigned through ADE to enroll before acting on it.
+data "iru_device_enrollment" "example" {
+  serial_number = "C02XXXXXXXXX"
+  timeout       = "45m"
+  poll_interval = "1m"
+
+  depends_on = [iru_ade_device.example]
+}
+
+action "iru_device_action_set_name" "example" {
+  device_id   = data.iru_device_enrollment.example.device_id
+  device_name = "lab-mac-01"
+}
@@ -0,0 +1,18 @@
+# List every active profile and script whose name starts with "Security"
+data "iru_library_items" "security" {
+  types      = ["custom_profile", "custom_script"]
+  name_regex = "^Security"
+  active     = true
+}
+
+# Look up a single library item by name and assign it to a blueprint
+data "iru_library_items" "zoom" {
+  name        = "Zoom"
+  types       = ["custom_app"]
+  exactly_one = true
+}
+
+resource "iru_blueprint_library_item" "zoom" {
+  blueprint_id    = "00000000-0000-0000-0000-000000000000"
+  library_item_id = data.iru_library_items.zoom.library_item_id
+}
@@ -0,0 +1,19 @@
+# Catch admin accounts outside the managed set.
+data "iru_local_admin_report" "fleet" {
+  allowed_admins         = ["admin"]
+  allowed_admin_patterns = ["it-*"]
+}
+
+output "unexpected_admins" {
+  value = {
+    for d in data.iru_local_admin_report.fleet.devices : d.device_name => d.unexpected_admins[*].username
+    if length(d.unexpected_admins) > 0
+  }
+}
+
+check "local_admins" {
+  assert {
+    condition     = data.iru_local_admin_report.fleet.unexpected_admin_count == 0 && data.iru_local_admin_report.fleet.hidden_admin_count == 0
+    error_message = "${data.iru_local_admin_report.fleet.unexpected_admin_count} unexpected and ${data.iru_local_admin_report.fleet.hidden_admin_count} hidden admin accounts found."
+  }
+}
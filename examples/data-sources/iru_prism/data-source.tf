@@ -0,0 +1,24 @@
+data "iru_prism" "local_users" {
+  category        = "local_users"
+  device_families = ["Mac"]
+  sort_by         = "-username"
+
+  filter = {
+    conditions = [
+      { field = "uid", operator = "gte", value = "501" },
+    ]
+    groups = [
+      {
+        match = "any"
+        conditions = [
+          { field = "hidden_user", operator = "eq", value = "true" },
+          { field = "username", operator = "in", values = ["admin", "support"] },
+        ]
+      },
+    ]
+  }
+}
+
+output "suspicious_users" {
+  value = [for row in data.iru_prism.local_users.rows : "${row.device__name}: ${row.username}"]
+}
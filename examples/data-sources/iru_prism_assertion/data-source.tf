@@ -0,0 +1,33 @@
+# At least 98% of Macs have FileVault on with an escrowed recovery key.
+data "iru_prism_assertion" "filevault" {
+  category        = "filevault"
+  device_families = ["Mac"]
+
+  predicate = {
+    conditions = [
+      { field = "status", operator = "eq", value = "true" },
+      { field = "key_escrowed", operator = "eq", value = "true" },
+    ]
+  }
+  min_percentage = 98
+}
+
+check "filevault" {
+  assert {
+    condition     = data.iru_prism_assertion.filevault.passed
+    error_message = "Only ${data.iru_prism_assertion.filevault.percentage}% of Macs have FileVault with an escrowed key."
+  }
+}
+
+# No Mac may run with System Integrity Protection disabled; fail the plan otherwise.
+data "iru_prism_assertion" "sip" {
+  category = "startup_settings"
+
+  predicate = {
+    conditions = [
+      { field = "sip", operator = "eq", value = "true" },
+    ]
+  }
+  max_violations   = 0
+  error_on_failure = true
+}
@@ -3,3 +3,16 @@ data "iru_prism_certificates" "example" {}
 output "certs" {
   value = data.iru_prism_certificates.example.results
 }
+
+# Certificates that expire in the next 30 days show up as a plan warning.
+data "iru_prism_certificates" "expiring" {
+  expiring_within = "30d"
+  expired         = false
+}
+
+output "expiring_certificates" {
+  value = {
+    for group in data.iru_prism_certificates.expiring.summary :
+    "${group.subject} (${group.issuer})" => group.min_days_until_expiry
+  }
+}
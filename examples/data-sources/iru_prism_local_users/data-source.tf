@@ -3,3 +3,11 @@ data "iru_prism_local_users" "example" {}
 output "users" {
   value = data.iru_prism_local_users.example.results
 }
+
+data "iru_prism_local_users" "hidden" {
+  filter = {
+    conditions = [
+      { field = "hidden_user", operator = "eq", value = "true" },
+    ]
+  }
+}
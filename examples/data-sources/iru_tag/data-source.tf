@@ -0,0 +1,7 @@
+data "iru_tag" "example" {
+  name = "Finance"
+}
+
+output "tag_id" {
+  value = data.iru_tag.example.id
+}
@@ -0,0 +1,12 @@
+data "iru_tag_devices" "pilot" {
+  names = ["macos-pilot", "engineering"]
+  match = "all"
+}
+
+output "pilot_tag_id" {
+  value = data.iru_tag_devices.pilot.ids["macos-pilot"]
+}
+
+output "pilot_engineering_devices" {
+  value = data.iru_tag_devices.pilot.device_ids
+}
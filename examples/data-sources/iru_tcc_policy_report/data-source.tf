@@ -0,0 +1,27 @@
+# Only the backup agent and the security agent's team may hold Full Disk
+# Access, and nothing may record the screen or control the computer.
+data "iru_tcc_policy_report" "fleet" {
+  allowlist = {
+    full_disk_access = {
+      bundle_ids = ["com.example.backup"]
+      team_ids   = ["X9E956P446"]
+    }
+    screen_recording = {}
+    Accessibility    = {}
+  }
+}
+
+output "unexpected_tcc_grants" {
+  value = {
+    for d in data.iru_tcc_policy_report.fleet.devices : d.device_name => [
+      for g in d.unexpected_grants : "${g.service}: ${g.application}"
+    ] if length(d.unexpected_grants) > 0
+  }
+}
+
+check "tcc_policy" {
+  assert {
+    condition     = data.iru_tcc_policy_report.fleet.unexpected_grant_count == 0
+    error_message = "${data.iru_tcc_policy_report.fleet.unexpected_grant_count} unexpected privacy grants found."
+  }
+}
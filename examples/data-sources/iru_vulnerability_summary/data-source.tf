@@ -0,0 +1,17 @@
+# Fail the plan in CI when critical or high vulnerabilities pile up.
+data "iru_vulnerability_summary" "fleet" {
+  max_critical = 0
+  max_high     = 25
+}
+
+output "vulnerabilities_by_severity" {
+  value = data.iru_vulnerability_summary.fleet.severity_counts
+}
+
+output "most_affected_software" {
+  value = [for s in data.iru_vulnerability_summary.fleet.top_software : "${s.name}: ${s.device_count} devices"]
+}
+
+output "oldest_vulnerability_age_days" {
+  value = data.iru_vulnerability_summary.fleet.oldest_detection_age_days
+}
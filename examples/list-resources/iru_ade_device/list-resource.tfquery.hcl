@@ -5,6 +5,17 @@ list "iru_ade_device" "all" {
   limit            = 100
 }
 
+# Query only the unenrolled devices of one DEP account
+list "iru_ade_device" "unenrolled" {
+  provider = iru
+
+  config {
+    dep_account          = "Engineering"
+    serial_number_prefix = "C02"
+    is_enrolled          = false
+  }
+}
+
 # Use the queried results for bulk import into managed resources
 /*
 import {
@@ -5,6 +5,16 @@ list "iru_blueprint" "all" {
   limit            = 100
 }
 
+# Query blueprints by name pattern
+list "iru_blueprint" "production" {
+  provider = iru
+
+  config {
+    name_regex    = "^Production"
+    created_after = "2025-01-01T00:00:00Z"
+  }
+}
+
 # Use the queried results for bulk import into managed resources
 /*
 import {
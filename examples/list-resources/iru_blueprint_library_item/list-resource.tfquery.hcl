@@ -0,0 +1,22 @@
+# Query the library items assigned to a blueprint
+list "iru_blueprint_library_item" "engineering" {
+  provider         = iru
+  include_resource = true
+
+  config {
+    blueprint_id = "00000000-0000-0000-0000-000000000000"
+  }
+}
+
+# Use the queried results for bulk import into managed resources
+/*
+import {
+  for_each = list.iru_blueprint_library_item.engineering
+  to       = iru_blueprint_library_item.managed[each.key]
+  id       = each.value.id
+}
+
+resource "iru_blueprint_library_item" "managed" {
+  for_each = list.iru_blueprint_library_item.engineering
+}
+*/
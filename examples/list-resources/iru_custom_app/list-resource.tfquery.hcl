@@ -5,6 +5,16 @@ list "iru_custom_app" "all" {
   limit            = 100
 }
 
+# Query only active custom apps
+list "iru_custom_app" "active" {
+  provider = iru
+
+  config {
+    active     = true
+    name_regex = "(?i)zoom"
+  }
+}
+
 # Use the queried results for bulk import into managed resources
 /*
 import {
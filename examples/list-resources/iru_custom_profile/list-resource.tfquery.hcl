@@ -5,6 +5,16 @@ list "iru_custom_profile" "all" {
   limit            = 100
 }
 
+# Query active custom profiles that run on iPad
+list "iru_custom_profile" "ipad" {
+  provider = iru
+
+  config {
+    active   = true
+    platform = "iPad"
+  }
+}
+
 # Use the queried results for bulk import into managed resources
 /*
 import {
@@ -5,6 +5,16 @@ list "iru_custom_script" "all" {
   limit            = 100
 }
 
+# Query custom scripts created this year
+list "iru_custom_script" "recent" {
+  provider = iru
+
+  config {
+    name_regex    = "^Onboarding"
+    created_after = "2025-01-01T00:00:00Z"
+  }
+}
+
 # Use the queried results for bulk import into managed resources
 /*
 import {
@@ -6,10 +6,10 @@ list "iru_device" "macbooks" {
   include_resource = true
   limit            = 500
 
-  # Example of provider-specific query configuration if supported
-  # config {
-  #   platform = "macOS"
-  # }
+  config {
+    platform   = "Mac"
+    name_regex = "^MBP-"
+  }
 }
 
 # After running 'terraform query', you can use the results in an import block:
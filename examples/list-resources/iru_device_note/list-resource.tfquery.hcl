@@ -0,0 +1,32 @@
+# Query the notes of a single device
+list "iru_device_note" "device" {
+  provider         = iru
+  include_resource = true
+
+  config {
+    device_id = "00000000-0000-0000-0000-000000000000"
+  }
+}
+
+# Query the notes of every Mac in a blueprint
+list "iru_device_note" "engineering_macs" {
+  provider = iru
+
+  config {
+    blueprint_id = "00000000-0000-0000-0000-000000000000"
+    platform     = "Mac"
+  }
+}
+
+# Use the queried results for bulk import into managed resources
+/*
+import {
+  for_each = list.iru_device_note.device
+  to       = iru_device_note.managed[each.key]
+  id       = each.value.id
+}
+
+resource "iru_device_note" "managed" {
+  for_each = list.iru_device_note.device
+}
+*/
@@ -5,6 +5,16 @@ list "iru_in_house_app" "all" {
   limit            = 100
 }
 
+# Query active in-house apps that run on iPhone
+list "iru_in_house_app" "iphone" {
+  provider = iru
+
+  config {
+    active   = true
+    platform = "iPhone"
+  }
+}
+
 # Use the queried results for bulk import into managed resources
 /*
 import {
@@ -0,0 +1,5 @@
+# Query existing self service categories
+list "iru_self_service_category" "all" {
+  provider         = iru
+  include_resource = true
+}
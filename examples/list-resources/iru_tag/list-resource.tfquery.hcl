@@ -5,6 +5,15 @@ list "iru_tag" "all" {
   limit            = 100
 }
 
+# Query tags by name pattern
+list "iru_tag" "departments" {
+  provider = iru
+
+  config {
+    name_regex = "^dept-"
+  }
+}
+
 # Use the queried results for bulk import into managed resources
 /*
 import {
@@ -0,0 +1,10 @@
+# Query users that are not archived
+list "iru_user" "active" {
+  provider         = iru
+  include_resource = true
+
+  config {
+    active     = true
+    name_regex = "(?i)^a"
+  }
+}
@@ -0,0 +1,20 @@
+resource "iru_ade_assignment_policy" "example" {
+  rules = [
+    {
+      # MacBook Pros purchased through the engineering DEP account.
+      model        = "MacBook Pro*"
+      dep_account  = "Engineering*"
+      blueprint_id = iru_blueprint.engineering.id
+    },
+    {
+      match_type    = "regex"
+      device_family = "^iPad$"
+      blueprint_id  = iru_blueprint.kiosk.id
+    },
+    {
+      # Every other Mac.
+      os           = "OSX"
+      blueprint_id = iru_blueprint.standard.id
+    },
+  ]
+}
@@ -0,0 +1,19 @@
+locals {
+  # Serial numbers from the purchasing feed, e.g. decoded from a CSV file.
+  purchases = csvdecode(file("${path.module}/purchases.csv"))
+}
+
+resource "iru_ade_device_assignments" "quarterly" {
+  max_concurrency = 10
+
+  assignments = {
+    for row in local.purchases : row.serial_number => {
+      blueprint_id = row.blueprint_id
+      asset_tag    = row.asset_tag
+    }
+  }
+}
+
+output "missing_serials" {
+  value = iru_ade_device_assignments.quarterly.missing_serials
+}
@@ -1,6 +1,9 @@
 resource "iru_ade_integration" "example" {
-  blueprint_id          = "your-blueprint-uuid"
-  phone                 = "1234567890"
-  email                 = "admin@example.com"
-  mdm_server_token_file = file("${path.module}/token.p7m")
+  blueprint_id        = "your-blueprint-uuid"
+  phone               = "1234567890"
+  email               = "admin@example.com"
+  mdm_server_token_wo = filebase64("${path.module}/token.p7m")
+
+  # Warn during plan when the token has less than 45 days left.
+  expiry_warning_days = 45
 }
@@ -0,0 +1,8 @@
+# Read the token from a file during plan and apply. Replacing the file with a
+# renewed token from Apple Business Manager renews the integration.
+resource "iru_ade_integration" "from_path" {
+  phone                 = "1234567890"
+  email                 = "admin@example.com"
+  use_blueprint_routing = true
+  mdm_server_token_path = "${path.module}/token.p7m"
+}
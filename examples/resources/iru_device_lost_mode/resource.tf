@@ -0,0 +1,6 @@
+resource "iru_device_lost_mode" "example" {
+  device_id    = "your-device-uuid"
+  message      = "This device is lost. Please call me."
+  phone_number = "555-123-4567"
+  footnote     = "Reward if found."
+}
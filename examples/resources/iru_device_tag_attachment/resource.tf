@@ -0,0 +1,15 @@
+data "iru_devices" "pilot" {
+  blueprint_id = "00000000-0000-0000-0000-000000000000"
+}
+
+resource "iru_tag" "pilot" {
+  name = "macos-pilot"
+}
+
+# Adds the tag to each device and leaves its other tags untouched
+resource "iru_device_tag_attachment" "pilot" {
+  for_each = { for device in data.iru_devices.pilot.devices : device.id => device }
+
+  device_id = each.key
+  tag_id    = iru_tag.pilot.id
+}
@@ -0,0 +1,16 @@
+resource "iru_tag" "engineering" {
+  name = "engineering"
+}
+
+resource "iru_tag" "pilot" {
+  name = "macos-pilot"
+}
+
+# Replaces every tag of the device with exactly these tags
+resource "iru_device_tags" "build_agent" {
+  device_id = "00000000-0000-0000-0000-000000000000"
+  tag_ids = [
+    iru_tag.engineering.id,
+    iru_tag.pilot.id,
+  ]
+}
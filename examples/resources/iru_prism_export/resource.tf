@@ -2,3 +2,27 @@ resource "iru_prism_export" "apps_export" {
   category        = "apps"
   device_families = ["Mac"]
 }
+
+resource "iru_prism_export" "outdated_chrome" {
+  category = "apps"
+  filter = {
+    conditions = [
+      { field = "bundle_id", operator = "eq", value = "com.google.Chrome" },
+      { field = "version", operator = "lt", value = "120" },
+    ]
+  }
+  sort_by = "device__name"
+}
+
+# Wait for the export, keep a copy of the CSV and read its rows.
+resource "iru_prism_export" "filevault" {
+  category            = "filevault"
+  wait_for_completion = true
+  timeout             = "15m"
+  output_path         = "${path.module}/filevault.csv"
+  parse_csv           = true
+}
+
+output "unescrowed_devices" {
+  value = [for row in iru_prism_export.filevault.rows : row["device__name"] if row["key_escrowed"] != "true"]
+}
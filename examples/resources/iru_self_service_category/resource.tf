@@ -0,0 +1,15 @@
+resource "iru_self_service_category" "utilities" {
+  name = "Utilities"
+}
+
+resource "iru_custom_script" "flush_dns" {
+  name                     = "Flush DNS Cache"
+  execution_frequency      = "no_enforcement"
+  show_in_self_service     = true
+  self_service_category_id = iru_self_service_category.utilities.id
+  script                   = <<-EOT
+    #!/bin/zsh
+    dscacheutil -flushcache
+    killall -HUP mDNSResponder
+  EOT
+}
@@ -17,8 +17,18 @@ type Client struct {
 	HTTPClient *http.Client
 	APIURL     string
 	APIToken   string
+
+	// Interceptors are called in order before each request is sent. See
+	// RequestInterceptor.
+	Interceptors []RequestInterceptor
 }
 
+// RequestInterceptor is called with every request before it is sent, along
+// with a copy of the request body. Returning a non-nil response short-circuits
+// the request: it is not sent and the returned response is handled as if the
+// API had returned it. Returning an error aborts the request.
+type RequestInterceptor func(ctx context.Context, req *http.Request, body []byte) (*http.Response, error)
+
 // NewClient creates a new Iru API client.
 func NewClient(apiURL, apiToken string) *Client {
 	return &Client{
@@ -30,42 +40,16 @@ func NewClient(apiURL, apiToken string) *Client {
 
 // DoRequest performs an HTTP request to the Iru API.
 func (c *Client) DoRequest(ctx context.Context, method, path string, body interface{}, response interface{}) error {
-	var reqBody io.Reader
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			return fmt.Errorf("error marshaling request body: %w", err)
 		}
-		reqBody = bytes.NewBuffer(jsonBody)
-	}
-
-	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s%s", c.APIURL, path), reqBody)
-	if err != nil {
-		return fmt.Errorf("error creating request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.APIToken)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("error performing request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 400 {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API error: status=%d body=%s", resp.StatusCode, string(bodyBytes))
-	}
-
-	if response != nil && resp.StatusCode != http.StatusNoContent {
-		if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
-			return fmt.Errorf("error decoding response: %w", err)
-		}
-	}
-
-	return nil
+	return c.send(ctx, method, path, "application/json", jsonBody, response)
 }
 
 // DoMultipartRequest performs a multipart/form-data request to the Iru API.
@@ -93,16 +77,40 @@ func (c *Client) DoMultipartRequest(ctx context.Context, method, path string, fi
 		return fmt.Errorf("error closing multipart writer: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s%s", c.APIURL, path), body)
+	return c.send(ctx, method, path, writer.FormDataContentType(), body.Bytes(), response)
+}
+
+// send performs a request with an already encoded body, passing it through the
+// client's interceptors first.
+func (c *Client) send(ctx context.Context, method, path, contentType string, body []byte, response interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s%s", c.APIURL, path), reqBody)
 	if err != nil {
 		return fmt.Errorf("error creating request: %w", err)
 	}
 
 	req.Header.Set("Authorization", "Bearer "+c.APIToken)
-	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Content-Type", contentType)
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := c.HTTPClient.Do(req)
+	var resp *http.Response
+	for _, intercept := range c.Interceptors {
+		resp, err = intercept(ctx, req, body)
+		if err != nil {
+			return err
+		}
+		if resp != nil {
+			break
+		}
+	}
+
+	if resp == nil {
+		resp, err = c.HTTPClient.Do(req)
+	}
 	if err != nil {
 		return fmt.Errorf("error performing request: %w", err)
 	}
@@ -121,3 +129,28 @@ func (c *Client) DoMultipartRequest(ctx context.Context, method, path string, fi
 
 	return nil
 }
+
+// Download fetches a URL outside the Iru API, such as the signed URL of an
+// export, and copies the response body to w. The API token is not sent.
+func (c *Client) Download(ctx context.Context, url string, w io.Writer) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error performing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("download error: status=%d body=%s", resp.StatusCode, string(bodyBytes))
+	}
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("error reading download: %w", err)
+	}
+	return nil
+}
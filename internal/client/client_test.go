@@ -118,3 +118,25 @@ func TestDoMultipartRequest(t *testing.T) {
 		}
 	})
 }
+
+func TestDownload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Errorf("Expected no Authorization header, got %s", r.Header.Get("Authorization"))
+		}
+		if r.URL.Query().Get("signature") != "abc" {
+			t.Errorf("Expected the signature to be kept, got %s", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte("a,b\n1,2\n"))
+	}))
+	defer server.Close()
+
+	c := NewClient("https://api.iru.io", "test-token")
+	var body strings.Builder
+	if err := c.Download(context.Background(), server.URL+"/export.csv?signature=abc", &body); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if body.String() != "a,b\n1,2\n" {
+		t.Errorf("Unexpected body %q", body.String())
+	}
+}
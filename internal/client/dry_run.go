@@ -0,0 +1,189 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// secretKeys are substrings of field names whose values are treated as
+// secrets.
+var secretKeys = []string{"pin", "password", "passcode", "token", "secret", "key"}
+
+// DryRunRequest describes a mutating request that dry-run mode did not send.
+type DryRunRequest struct {
+	Method string
+	Path   string
+	Body   string
+}
+
+func (r DryRunRequest) String() string {
+	if r.Body == "" {
+		return fmt.Sprintf("%s %s", r.Method, r.Path)
+	}
+	return fmt.Sprintf("%s %s %s", r.Method, r.Path, r.Body)
+}
+
+// DryRunError is returned for a mutating request that was not sent because
+// dry-run mode is enabled and no DryRunRecorder is attached to the context.
+type DryRunError struct {
+	Request DryRunRequest
+}
+
+func (e *DryRunError) Error() string {
+	return fmt.Sprintf("dry run: request was not sent: %s", e.Request)
+}
+
+// DryRunRecorder collects the requests skipped by dry-run mode for a context.
+type DryRunRecorder struct {
+	mu       sync.Mutex
+	requests []DryRunRequest
+}
+
+type dryRunRecorderKey struct{}
+
+// WithDryRunRecorder returns a context whose skipped mutating requests are
+// recorded and reported as successful instead of returning a DryRunError.
+func WithDryRunRecorder(ctx context.Context) (context.Context, *DryRunRecorder) {
+	recorder := &DryRunRecorder{}
+	return context.WithValue(ctx, dryRunRecorderKey{}, recorder), recorder
+}
+
+// Requests returns the requests recorded so far.
+func (r *DryRunRecorder) Requests() []DryRunRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]DryRunRequest(nil), r.requests...)
+}
+
+func (r *DryRunRecorder) record(req DryRunRequest) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, req)
+}
+
+// DryRunInterceptor lets GET and HEAD requests through and logs every other
+// request as a warning instead of sending it. Sensitive body fields are
+// redacted.
+func DryRunInterceptor(ctx context.Context, req *http.Request, body []byte) (*http.Response, error) {
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return nil, nil
+	}
+
+	skipped := DryRunRequest{
+		Method: req.Method,
+		Path:   req.URL.RequestURI(),
+		Body:   RedactBody(req.Header.Get("Content-Type"), body),
+	}
+
+	tflog.Warn(ctx, "Dry run: request not sent", map[string]interface{}{
+		"method": skipped.Method,
+		"path":   skipped.Path,
+		"body":   skipped.Body,
+	})
+
+	recorder, ok := ctx.Value(dryRunRecorderKey{}).(*DryRunRecorder)
+	if !ok {
+		return nil, &DryRunError{Request: skipped}
+	}
+	recorder.record(skipped)
+
+	return &http.Response{
+		StatusCode: http.StatusNoContent,
+		Body:       io.NopCloser(bytes.NewReader(nil)),
+		Request:    req,
+	}, nil
+}
+
+// RedactBody renders a JSON or multipart request body for logging, replacing
+// the values of sensitive fields and omitting file contents.
+func RedactBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	mediaType, params, _ := mime.ParseMediaType(contentType)
+	if mediaType == "multipart/form-data" {
+		return redactMultipart(body, params["boundary"])
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return fmt.Sprintf("<%d bytes>", len(body))
+	}
+	redacted, _ := json.Marshal(redactValue(decoded))
+	return string(redacted)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if IsSecretKey(key) {
+				v[key] = "REDACTED"
+				continue
+			}
+			v[key] = redactValue(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+	return value
+}
+
+// IsSecretKey reports whether the value of a request body field or action
+// argument with the given name is a secret, such as a PIN or a token, that must
+// never be logged or recorded.
+func IsSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, secret := range secretKeys {
+		if strings.Contains(key, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactMultipart(body []byte, boundary string) string {
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+	fields := map[string]string{}
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			break
+		}
+		content, _ := io.ReadAll(part)
+		switch {
+		case part.FileName() != "":
+			fields[part.FormName()] = fmt.Sprintf("<file %s, %d bytes>", part.FileName(), len(content))
+		case IsSecretKey(part.FormName()):
+			fields[part.FormName()] = "REDACTED"
+		default:
+			fields[part.FormName()] = string(content)
+		}
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s=%s", name, fields[name]))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDryRunInterceptor(t *testing.T) {
+	newServer := func(t *testing.T) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				t.Errorf("Expected only GET requests to be sent, got %s %s", r.Method, r.URL.Path)
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"foo": "bar"}`))
+		}))
+	}
+
+	t.Run("get requests are sent", func(t *testing.T) {
+		server := newServer(t)
+		defer server.Close()
+
+		c := NewClient(server.URL, "test-token")
+		c.Interceptors = append(c.Interceptors, DryRunInterceptor)
+
+		var respData map[string]string
+		err := c.DoRequest(context.Background(), "GET", "/api/v1/test", nil, &respData)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if respData["foo"] != "bar" {
+			t.Errorf("Expected foo=bar, got %s", respData["foo"])
+		}
+	})
+
+	t.Run("mutating request without recorder", func(t *testing.T) {
+		server := newServer(t)
+		defer server.Close()
+
+		c := NewClient(server.URL, "test-token")
+		c.Interceptors = append(c.Interceptors, DryRunInterceptor)
+
+		err := c.DoRequest(context.Background(), "POST", "/api/v1/devices/abc/action/lock", map[string]string{"PIN": "123456"}, nil)
+		var dryRunErr *DryRunError
+		if !errors.As(err, &dryRunErr) {
+			t.Fatalf("Expected DryRunError, got %v", err)
+		}
+		if dryRunErr.Request.Method != "POST" || dryRunErr.Request.Path != "/api/v1/devices/abc/action/lock" {
+			t.Errorf("Unexpected request: %v", dryRunErr.Request)
+		}
+		if strings.Contains(dryRunErr.Request.Body, "123456") {
+			t.Errorf("Expected PIN to be redacted, got %s", dryRunErr.Request.Body)
+		}
+	})
+
+	t.Run("mutating request with recorder", func(t *testing.T) {
+		server := newServer(t)
+		defer server.Close()
+
+		c := NewClient(server.URL, "test-token")
+		c.Interceptors = append(c.Interceptors, DryRunInterceptor)
+
+		ctx, recorder := WithDryRunRecorder(context.Background())
+		var respData map[string]string
+		err := c.DoRequest(ctx, "PATCH", "/api/v1/tags/1", map[string]string{"name": "new"}, &respData)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		requests := recorder.Requests()
+		if len(requests) != 1 {
+			t.Fatalf("Expected 1 recorded request, got %d", len(requests))
+		}
+		if requests[0].String() != `PATCH /api/v1/tags/1 {"name":"new"}` {
+			t.Errorf("Unexpected recorded request: %s", requests[0])
+		}
+	})
+
+	t.Run("multipart request with recorder", func(t *testing.T) {
+		server := newServer(t)
+		defer server.Close()
+
+		c := NewClient(server.URL, "test-token")
+		c.Interceptors = append(c.Interceptors, DryRunInterceptor)
+
+		ctx, recorder := WithDryRunRecorder(context.Background())
+		fields := map[string]string{"email": "admin@example.com", "token": "hunter2"}
+		err := c.DoMultipartRequest(ctx, "POST", "/api/v1/upload", fields, "file", "token.p7m", strings.NewReader("secret"), nil)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		requests := recorder.Requests()
+		if len(requests) != 1 {
+			t.Fatalf("Expected 1 recorded request, got %d", len(requests))
+		}
+		expected := "email=admin@example.com file=<file token.p7m, 6 bytes> token=REDACTED"
+		if requests[0].Body != expected {
+			t.Errorf("Expected body %q, got %q", expected, requests[0].Body)
+		}
+	})
+}
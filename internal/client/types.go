@@ -8,6 +8,7 @@ type Blueprint struct {
 	Icon           string `json:"icon,omitempty"`
 	Color          string `json:"color,omitempty"`
 	Type           string `json:"type,omitempty"`
+	CreatedAt      string `json:"created_at,omitempty"`
 	EnrollmentCode struct {
 		Code     string `json:"code"`
 		IsActive bool   `json:"is_active"`
@@ -52,6 +53,7 @@ type Device struct {
 	UserID       string `json:"user_id,omitempty"`
 	Platform     string `json:"platform,omitempty"`
 	LastCheckIn  string `json:"last_check_in,omitempty"`
+	Tags         []Tag  `json:"tags,omitempty"`
 }
 
 // DeviceNote represents a note assigned to a device.
@@ -63,6 +65,14 @@ type DeviceNote struct {
 	Content   string `json:"content"`
 }
 
+// DeviceLostMode represents the Lost Mode details of a device.
+type DeviceLostMode struct {
+	Status      string `json:"status"`
+	Message     string `json:"message"`
+	PhoneNumber string `json:"phone_number"`
+	Footnote    string `json:"footnote"`
+}
+
 // DeviceDetails represents the full details of a device.
 type DeviceDetails struct {
 	General struct {
@@ -104,16 +114,25 @@ type Tag struct {
 	Name string `json:"name"`
 }
 
+// SelfServiceCategory represents an Iru Self Service category.
+type SelfServiceCategory struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
 // CustomScript represents an Iru Custom Script library item.
 type CustomScript struct {
-	ID                 string `json:"id,omitempty"`
-	Name               string `json:"name"`
-	Active             bool   `json:"active"`
-	ExecutionFrequency string `json:"execution_frequency"`
-	Restart            bool   `json:"restart"`
-	Script             string `json:"script"`
-	RemediationScript  string `json:"remediation_script,omitempty"`
-	ShowInSelfService  bool   `json:"show_in_self_service"`
+	ID                     string `json:"id,omitempty"`
+	Name                   string `json:"name"`
+	Active                 bool   `json:"active"`
+	ExecutionFrequency     string `json:"execution_frequency"`
+	Restart                bool   `json:"restart"`
+	Script                 string `json:"script"`
+	RemediationScript      string `json:"remediation_script,omitempty"`
+	ShowInSelfService      bool   `json:"show_in_self_service"`
+	SelfServiceCategoryID  string `json:"self_service_category_id,omitempty"`
+	SelfServiceRecommended bool   `json:"self_service_recommended"`
+	CreatedAt              string `json:"created_at,omitempty"`
 }
 
 // CustomProfile represents an Iru Custom Profile library item.
@@ -128,6 +147,7 @@ type CustomProfile struct {
 	RunsOnIPad    bool   `json:"runs_on_ipad"`
 	RunsOnTV      bool   `json:"runs_on_tv"`
 	RunsOnVision  bool   `json:"runs_on_vision"`
+	CreatedAt     string `json:"created_at,omitempty"`
 }
 
 // User represents an Iru User.
@@ -184,6 +204,41 @@ type Vulnerability struct {
 	Software           []string `json:"software"`
 }
 
+// VulnerabilityDetection represents a vulnerability detected in a piece of
+// software on a device. Fields the API may omit are pointers so that absent
+// values can be told apart from zero values.
+type VulnerabilityDetection struct {
+	CVEID               string   `json:"cve_id"`
+	DeviceID            *string  `json:"device_id"`
+	DeviceName          *string  `json:"device_name"`
+	DeviceSerialNumber  *string  `json:"device_serial_number"`
+	BlueprintID         *string  `json:"blueprint_id"`
+	BlueprintName       *string  `json:"blueprint_name"`
+	Name                *string  `json:"name"`
+	Version             *string  `json:"version"`
+	BundleID            *string  `json:"bundle_id"`
+	Path                *string  `json:"path"`
+	Severity            *string  `json:"cvss_severity"`
+	CVSSScore           *float64 `json:"cvss_score"`
+	FirstDetectionDate  *string  `json:"first_detection_date"`
+	LatestDetectionDate *string  `json:"latest_detection_date"`
+	Status              *string  `json:"status"`
+}
+
+// VulnerableDevice represents a device affected by a vulnerability.
+type VulnerableDevice struct {
+	DeviceID     *string `json:"device_id"`
+	Name         *string `json:"name"`
+	SerialNumber *string `json:"serial_number"`
+}
+
+// VulnerableSoftware represents a piece of software affected by a
+// vulnerability.
+type VulnerableSoftware struct {
+	Name     *string `json:"name"`
+	BundleID *string `json:"bundle_id"`
+}
+
 // CustomApp represents an Iru Custom App library item.
 type CustomApp struct {
 	ID                     string `json:"id,omitempty"`
@@ -200,6 +255,7 @@ type CustomApp struct {
 	SelfServiceRecommended bool   `json:"self_service_recommended"`
 	Active                 bool   `json:"active"`
 	Restart                bool   `json:"restart"`
+	CreatedAt              string `json:"created_at,omitempty"`
 }
 
 // InHouseApp represents an Iru In-House App library item (.ipa).
@@ -211,6 +267,7 @@ type InHouseApp struct {
 	RunsOnIPad   bool   `json:"runs_on_ipad"`
 	RunsOnTV     bool   `json:"runs_on_tv"`
 	Active       bool   `json:"active"`
+	CreatedAt    string `json:"created_at,omitempty"`
 }
 
 // AuditEvent represents an audit log event.
@@ -44,7 +44,7 @@ func (a *deviceBlankPushAction) Configure(ctx context.Context, req action.Config
 	if req.ProviderData == nil {
 		return
 	}
-	a.client = req.ProviderData.(*client.Client)
+	a.client = req.ProviderData.(*actionData).client
 }
 
 func (a *deviceBlankPushAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
@@ -44,7 +44,7 @@ func (a *deviceBypassActivationLockAction) Configure(ctx context.Context, req ac
 	if req.ProviderData == nil {
 		return
 	}
-	a.client = req.ProviderData.(*client.Client)
+	a.client = req.ProviderData.(*actionData).client
 }
 
 func (a *deviceBypassActivationLockAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
@@ -44,7 +44,7 @@ func (a *deviceCancelLostModeAction) Configure(ctx context.Context, req action.C
 	if req.ProviderData == nil {
 		return
 	}
-	a.client = req.ProviderData.(*client.Client)
+	a.client = req.ProviderData.(*actionData).client
 }
 
 func (a *deviceCancelLostModeAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
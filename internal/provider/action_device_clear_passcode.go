@@ -44,7 +44,7 @@ func (a *deviceClearPasscodeAction) Configure(ctx context.Context, req action.Co
 	if req.ProviderData == nil {
 		return
 	}
-	a.client = req.ProviderData.(*client.Client)
+	a.client = req.ProviderData.(*actionData).client
 }
 
 func (a *deviceClearPasscodeAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
@@ -44,7 +44,7 @@ func (a *deviceDailyCheckinAction) Configure(ctx context.Context, req action.Con
 	if req.ProviderData == nil {
 		return
 	}
-	a.client = req.ProviderData.(*client.Client)
+	a.client = req.ProviderData.(*actionData).client
 }
 
 func (a *deviceDailyCheckinAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
@@ -59,7 +59,7 @@ func (a *deviceDeleteUserAction) Configure(ctx context.Context, req action.Confi
 	if req.ProviderData == nil {
 		return
 	}
-	a.client = req.ProviderData.(*client.Client)
+	a.client = req.ProviderData.(*actionData).client
 }
 
 func (a *deviceDeleteUserAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
@@ -17,18 +17,20 @@ func NewDeviceEraseAction() action.Action {
 }
 
 type deviceEraseAction struct {
-	client *client.Client
+	client      *client.Client
+	maintenance *maintenanceSchedule
 }
 
 type deviceEraseActionModel struct {
-	DeviceID               types.String `tfsdk:"device_id"`
-	PIN                    types.String `tfsdk:"pin"`
-	PreserveDataPlan       types.Bool   `tfsdk:"preserve_data_plan"`
-	DisallowProximitySetup types.Bool   `tfsdk:"disallow_proximity_setup"`
-	EraseMode              types.String `tfsdk:"erase_mode"`
-	EraseFlags             types.String `tfsdk:"erase_flags"`
-	ReturnToServiceEnabled types.Bool   `tfsdk:"return_to_service_enabled"`
-	ReturnToServiceProfile types.String `tfsdk:"return_to_service_profile"`
+	DeviceID                 types.String `tfsdk:"device_id"`
+	PIN                      types.String `tfsdk:"pin"`
+	PreserveDataPlan         types.Bool   `tfsdk:"preserve_data_plan"`
+	DisallowProximitySetup   types.Bool   `tfsdk:"disallow_proximity_setup"`
+	EraseMode                types.String `tfsdk:"erase_mode"`
+	EraseFlags               types.String `tfsdk:"erase_flags"`
+	ReturnToServiceEnabled   types.Bool   `tfsdk:"return_to_service_enabled"`
+	ReturnToServiceProfile   types.String `tfsdk:"return_to_service_profile"`
+	RespectMaintenanceWindow types.Bool   `tfsdk:"respect_maintenance_window"`
 }
 
 func (a *deviceEraseAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
@@ -69,6 +71,10 @@ func (a *deviceEraseAction) Schema(ctx context.Context, req action.SchemaRequest
 				Optional:            true,
 				MarkdownDescription: "The WiFi profile ID for Return to Service.",
 			},
+			"respect_maintenance_window": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only run the action inside one of the provider's `maintenance_windows`. Outside a window the action fails or waits, depending on the provider's `maintenance_window_action`.",
+			},
 		},
 	}
 }
@@ -77,7 +83,9 @@ func (a *deviceEraseAction) Configure(ctx context.Context, req action.ConfigureR
 	if req.ProviderData == nil {
 		return
 	}
-	a.client = req.ProviderData.(*client.Client)
+	data := req.ProviderData.(*actionData)
+	a.client = data.client
+	a.maintenance = data.maintenance
 }
 
 func (a *deviceEraseAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
@@ -88,6 +96,11 @@ func (a *deviceEraseAction) Invoke(ctx context.Context, req action.InvokeRequest
 		return
 	}
 
+	resp.Diagnostics.Append(checkMaintenanceWindow(ctx, a.maintenance, data.RespectMaintenanceWindow, resp)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	deviceID := data.DeviceID.ValueString()
 	payload := map[string]interface{}{}
 	if !data.PIN.IsNull() { payload["PIN"] = data.PIN.ValueString() }
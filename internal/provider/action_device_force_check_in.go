@@ -44,7 +44,7 @@ func (a *deviceForceCheckInAction) Configure(ctx context.Context, req action.Con
 	if req.ProviderData == nil {
 		return
 	}
-	a.client = req.ProviderData.(*client.Client)
+	a.client = req.ProviderData.(*actionData).client
 }
 
 func (a *deviceForceCheckInAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
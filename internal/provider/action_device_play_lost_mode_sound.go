@@ -44,7 +44,7 @@ func (a *devicePlayLostModeSoundAction) Configure(ctx context.Context, req actio
 	if req.ProviderData == nil {
 		return
 	}
-	a.client = req.ProviderData.(*client.Client)
+	a.client = req.ProviderData.(*actionData).client
 }
 
 func (a *devicePlayLostModeSoundAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
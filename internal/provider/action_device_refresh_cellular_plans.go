@@ -44,7 +44,7 @@ func (a *deviceRefreshCellularPlansAction) Configure(ctx context.Context, req ac
 	if req.ProviderData == nil {
 		return
 	}
-	a.client = req.ProviderData.(*client.Client)
+	a.client = req.ProviderData.(*actionData).client
 }
 
 func (a *deviceRefreshCellularPlansAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
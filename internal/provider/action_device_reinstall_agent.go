@@ -17,11 +17,13 @@ func NewDeviceReinstallAgentAction() action.Action {
 }
 
 type deviceReinstallAgentAction struct {
-	client *client.Client
+	client      *client.Client
+	maintenance *maintenanceSchedule
 }
 
 type deviceReinstallAgentActionModel struct {
-	DeviceID types.String `tfsdk:"device_id"`
+	DeviceID                 types.String `tfsdk:"device_id"`
+	RespectMaintenanceWindow types.Bool   `tfsdk:"respect_maintenance_window"`
 }
 
 func (a *deviceReinstallAgentAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
@@ -35,6 +37,10 @@ func (a *deviceReinstallAgentAction) Schema(ctx context.Context, req action.Sche
 			"device_id": schema.StringAttribute{
 				Required: true,
 			},
+			"respect_maintenance_window": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only run the action inside one of the provider's `maintenance_windows`. Outside a window the action fails or waits, depending on the provider's `maintenance_window_action`.",
+			},
 		},
 	}
 }
@@ -43,7 +49,9 @@ func (a *deviceReinstallAgentAction) Configure(ctx context.Context, req action.C
 	if req.ProviderData == nil {
 		return
 	}
-	a.client = req.ProviderData.(*client.Client)
+	data := req.ProviderData.(*actionData)
+	a.client = data.client
+	a.maintenance = data.maintenance
 }
 
 func (a *deviceReinstallAgentAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
@@ -53,6 +61,11 @@ func (a *deviceReinstallAgentAction) Invoke(ctx context.Context, req action.Invo
 		return
 	}
 
+	resp.Diagnostics.Append(checkMaintenanceWindow(ctx, a.maintenance, data.RespectMaintenanceWindow, resp)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	err := a.client.DoRequest(ctx, "POST", fmt.Sprintf("/api/v1/devices/%s/action/reinstallagent", data.DeviceID.ValueString()), nil, nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to invoke reinstall agent, got error: %s", err))
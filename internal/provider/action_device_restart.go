@@ -17,11 +17,13 @@ func NewDeviceRestartAction() action.Action {
 }
 
 type deviceRestartAction struct {
-	client *client.Client
+	client      *client.Client
+	maintenance *maintenanceSchedule
 }
 
 type deviceRestartActionModel struct {
-	DeviceID types.String `tfsdk:"device_id"`
+	DeviceID                 types.String `tfsdk:"device_id"`
+	RespectMaintenanceWindow types.Bool   `tfsdk:"respect_maintenance_window"`
 }
 
 func (a *deviceRestartAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
@@ -36,6 +38,10 @@ func (a *deviceRestartAction) Schema(ctx context.Context, req action.SchemaReque
 				Required:            true,
 				MarkdownDescription: "The unique identifier for the Device.",
 			},
+			"respect_maintenance_window": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only run the action inside one of the provider's `maintenance_windows`. Outside a window the action fails or waits, depending on the provider's `maintenance_window_action`.",
+			},
 		},
 	}
 }
@@ -44,7 +50,9 @@ func (a *deviceRestartAction) Configure(ctx context.Context, req action.Configur
 	if req.ProviderData == nil {
 		return
 	}
-	a.client = req.ProviderData.(*client.Client)
+	data := req.ProviderData.(*actionData)
+	a.client = data.client
+	a.maintenance = data.maintenance
 }
 
 func (a *deviceRestartAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
@@ -56,6 +64,11 @@ func (a *deviceRestartAction) Invoke(ctx context.Context, req action.InvokeReque
 		return
 	}
 
+	resp.Diagnostics.Append(checkMaintenanceWindow(ctx, a.maintenance, data.RespectMaintenanceWindow, resp)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	deviceID := data.DeviceID.ValueString()
 	err := a.client.DoRequest(ctx, "POST", fmt.Sprintf("/api/v1/devices/%s/action/restart", deviceID), nil, nil)
 	if err != nil {
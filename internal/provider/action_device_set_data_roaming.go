@@ -49,7 +49,7 @@ func (a *deviceSetDataRoamingAction) Configure(ctx context.Context, req action.C
 	if req.ProviderData == nil {
 		return
 	}
-	a.client = req.ProviderData.(*client.Client)
+	a.client = req.ProviderData.(*actionData).client
 }
 
 func (a *deviceSetDataRoamingAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
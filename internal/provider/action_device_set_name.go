@@ -49,7 +49,7 @@ func (a *deviceSetNameAction) Configure(ctx context.Context, req action.Configur
 	if req.ProviderData == nil {
 		return
 	}
-	a.client = req.ProviderData.(*client.Client)
+	a.client = req.ProviderData.(*actionData).client
 }
 
 func (a *deviceSetNameAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
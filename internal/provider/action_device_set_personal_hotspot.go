@@ -49,7 +49,7 @@ func (a *deviceSetPersonalHotspotAction) Configure(ctx context.Context, req acti
 	if req.ProviderData == nil {
 		return
 	}
-	a.client = req.ProviderData.(*client.Client)
+	a.client = req.ProviderData.(*actionData).client
 }
 
 func (a *deviceSetPersonalHotspotAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
@@ -49,7 +49,7 @@ func (a *deviceUnlockAccountAction) Configure(ctx context.Context, req action.Co
 	if req.ProviderData == nil {
 		return
 	}
-	a.client = req.ProviderData.(*client.Client)
+	a.client = req.ProviderData.(*actionData).client
 }
 
 func (a *deviceUnlockAccountAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
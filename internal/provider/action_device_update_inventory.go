@@ -44,7 +44,7 @@ func (a *deviceUpdateInventoryAction) Configure(ctx context.Context, req action.
 	if req.ProviderData == nil {
 		return
 	}
-	a.client = req.ProviderData.(*client.Client)
+	a.client = req.ProviderData.(*actionData).client
 }
 
 func (a *deviceUpdateInventoryAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// defaultSensitiveArguments are free-text action arguments that are left out
+// of action notes unless the provider configuration says otherwise.
+var defaultSensitiveArguments = []string{"message", "footnote", "phone_number"}
+
+// actionNoteSettings configures the device notes recorded after successful
+// actions.
+type actionNoteSettings struct {
+	runID              string
+	sensitiveArguments map[string]bool
+	now                func() time.Time
+}
+
+var _ action.ActionWithConfigure = &hookedAction{}
+
+// withActionHooks wraps an action constructor so that behavior shared by every
+// action, such as dry-run reporting and action notes, runs around its Invoke.
+func withActionHooks(newAction func() action.Action) func() action.Action {
+	return func() action.Action {
+		return &hookedAction{Action: newAction()}
+	}
+}
+
+type hookedAction struct {
+	action.Action
+	data *actionData
+}
+
+func (a *hookedAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if configurable, ok := a.Action.(action.ActionWithConfigure); ok {
+		configurable.Configure(ctx, req, resp)
+	}
+	if req.ProviderData == nil {
+		return
+	}
+	a.data = req.ProviderData.(*actionData)
+}
+
+func (a *hookedAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	if a.data == nil {
+		a.Action.Invoke(ctx, req, resp)
+		return
+	}
+
+	var recorder *client.DryRunRecorder
+	if a.data.dryRun {
+		ctx, recorder = client.WithDryRunRecorder(ctx)
+	}
+
+	a.Action.Invoke(ctx, req, resp)
+
+	if !resp.Diagnostics.HasError() && a.data.notes != nil {
+		a.recordActionNote(ctx, req, resp)
+	}
+
+	if recorder == nil {
+		return
+	}
+	requests := recorder.Requests()
+	if len(requests) == 0 {
+		return
+	}
+	lines := make([]string, 0, len(requests))
+	for _, r := range requests {
+		lines = append(lines, "  "+r.String())
+	}
+	resp.Diagnostics.AddWarning(
+		"Dry Run",
+		fmt.Sprintf("Dry-run mode is enabled. The action would have sent the following requests:\n%s", strings.Join(lines, "\n")),
+	)
+}
+
+// recordActionNote posts a device note describing the action that was just
+// invoked. A failure to post the note does not fail the action.
+func (a *hookedAction) recordActionNote(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var metadata action.MetadataResponse
+	a.Action.Metadata(ctx, action.MetadataRequest{ProviderTypeName: "iru"}, &metadata)
+
+	var config map[string]tftypes.Value
+	if err := req.Config.Raw.As(&config); err != nil {
+		resp.Diagnostics.AddWarning("Action Note Not Recorded", fmt.Sprintf("Unable to read the arguments of %s, got error: %s", metadata.TypeName, err))
+		return
+	}
+
+	var deviceID string
+	if value, ok := config["device_id"]; ok && value.IsKnown() && !value.IsNull() {
+		_ = value.As(&deviceID)
+	}
+	if deviceID == "" {
+		return
+	}
+
+	note := client.DeviceNote{
+		Content: actionNoteContent(metadata.TypeName, actionNoteArguments(config, a.data.notes.sensitiveArguments), a.data.notes.now(), a.data.notes.runID),
+	}
+	err := a.data.client.DoRequest(ctx, "POST", fmt.Sprintf("/api/v1/devices/%s/notes", deviceID), note, nil)
+	if err != nil {
+		resp.Diagnostics.AddWarning("Action Note Not Recorded", fmt.Sprintf("The action succeeded, but the device note could not be created, got error: %s", err))
+	}
+}
+
+// actionNoteArguments renders the non-secret arguments of an action as
+// name=value pairs. device_id, unset arguments, secrets and sensitive
+// arguments are left out.
+func actionNoteArguments(config map[string]tftypes.Value, sensitive map[string]bool) []string {
+	var args []string
+	for name, value := range config {
+		if name == "device_id" || sensitive[name] || client.IsSecretKey(name) || !value.IsKnown() || value.IsNull() {
+			continue
+		}
+
+		var rendered string
+		switch {
+		case value.Type().Is(tftypes.String):
+			_ = value.As(&rendered)
+		case value.Type().Is(tftypes.Bool):
+			var b bool
+			_ = value.As(&b)
+			rendered = strconv.FormatBool(b)
+		case value.Type().Is(tftypes.Number):
+			var n big.Float
+			_ = value.As(&n)
+			rendered = n.Text('f', -1)
+		default:
+			rendered = value.String()
+		}
+		args = append(args, fmt.Sprintf("%s=%s", name, rendered))
+	}
+	sort.Strings(args)
+	return args
+}
+
+// actionNoteContent builds the text of an action note.
+func actionNoteContent(actionName string, args []string, at time.Time, runID string) string {
+	lines := []string{
+		fmt.Sprintf("Terraform invoked %s.", actionName),
+		"Time: " + at.UTC().Format(time.RFC3339),
+	}
+	if runID != "" {
+		lines = append(lines, "Run: "+runID)
+	}
+	if len(args) > 0 {
+		lines = append(lines, "Arguments: "+strings.Join(args, ", "))
+	}
+	return strings.Join(lines, "\n")
+}
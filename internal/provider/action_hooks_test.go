@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestActionNoteContent(t *testing.T) {
+	config := map[string]tftypes.Value{
+		"device_id":                  tftypes.NewValue(tftypes.String, "abc"),
+		"pin":                        tftypes.NewValue(tftypes.String, "123456"),
+		"message":                    tftypes.NewValue(tftypes.String, "Call IT"),
+		"preserve_data_plan":         tftypes.NewValue(tftypes.Bool, true),
+		"notify_user":                tftypes.NewValue(tftypes.Bool, nil),
+		"respect_maintenance_window": tftypes.NewValue(tftypes.Bool, false),
+		"delay":                      tftypes.NewValue(tftypes.Number, big.NewFloat(30)),
+	}
+	sensitive := map[string]bool{"message": true}
+
+	args := actionNoteArguments(config, sensitive)
+	expected := []string{"delay=30", "preserve_data_plan=true", "respect_maintenance_window=false"}
+	if strings.Join(args, ",") != strings.Join(expected, ",") {
+		t.Fatalf("Expected arguments %v, got %v", expected, args)
+	}
+
+	at := time.Date(2026, 3, 1, 12, 30, 0, 0, time.FixedZone("EST", -5*60*60))
+	content := actionNoteContent("iru_device_action_erase", args, at, "https://ci.example.com/jobs/42")
+	want := "Terraform invoked iru_device_action_erase.\n" +
+		"Time: 2026-03-01T17:30:00Z\n" +
+		"Run: https://ci.example.com/jobs/42\n" +
+		"Arguments: delay=30, preserve_data_plan=true, respect_maintenance_window=false"
+	if content != want {
+		t.Errorf("Expected content %q, got %q", want, content)
+	}
+	if strings.Contains(content, "123456") || strings.Contains(content, "Call IT") {
+		t.Errorf("Expected secrets and sensitive arguments to be left out, got %q", content)
+	}
+}
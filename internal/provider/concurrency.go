@@ -0,0 +1,38 @@
+package provider
+
+import "sync"
+
+// defaultConcurrency is the number of concurrent API requests used when a
+// component fans out over many objects and the configuration does not say
+// otherwise.
+const defaultConcurrency = 5
+
+// forEachConcurrently calls fn for every index in [0, count) using at most
+// workers goroutines, and returns once every call has finished. fn must be safe
+// to call concurrently.
+func forEachConcurrently(workers, count int, fn func(i int)) {
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > count {
+		workers = count
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < count; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
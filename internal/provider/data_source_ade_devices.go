@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strings"
 
 	"github.com/MScottBlake/terraform-provider-iru/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -31,9 +32,26 @@ type adeDevicesDataSourceModel struct {
 	OS            types.String     `tfsdk:"os"`
 	ProfileStatus types.String     `tfsdk:"profile_status"`
 	SerialNumber  types.String     `tfsdk:"serial_number"`
+	SerialPrefix  types.String     `tfsdk:"serial_number_prefix"`
+	IsEnrolled    types.Bool       `tfsdk:"is_enrolled"`
 	Devices       []adeDeviceModel `tfsdk:"devices"`
 }
 
+// adeDeviceFilterModel holds the ADE device filters shared by the
+// iru_ade_devices data source and the iru_ade_device list resource.
+type adeDeviceFilterModel struct {
+	BlueprintID   types.String `tfsdk:"blueprint_id"`
+	UserID        types.String `tfsdk:"user_id"`
+	DEPAccount    types.String `tfsdk:"dep_account"`
+	DeviceFamily  types.String `tfsdk:"device_family"`
+	Model         types.String `tfsdk:"model"`
+	OS            types.String `tfsdk:"os"`
+	ProfileStatus types.String `tfsdk:"profile_status"`
+	SerialNumber  types.String `tfsdk:"serial_number"`
+	SerialPrefix  types.String `tfsdk:"serial_number_prefix"`
+	IsEnrolled    types.Bool   `tfsdk:"is_enrolled"`
+}
+
 type adeDeviceModel struct {
 	ID                  types.String `tfsdk:"id"`
 	SerialNumber        types.String `tfsdk:"serial_number"`
@@ -57,7 +75,7 @@ func (d *adeDevicesDataSource) Metadata(ctx context.Context, req datasource.Meta
 
 func (d *adeDevicesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "List all ADE devices in the Iru instance.",
+		MarkdownDescription: "List all ADE devices in the Iru instance. Filters are sent to the API where it supports them and applied by the provider otherwise, and every page of results is read.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -94,6 +112,14 @@ func (d *adeDevicesDataSource) Schema(ctx context.Context, req datasource.Schema
 				Optional:            true,
 				MarkdownDescription: "Filter by serial number.",
 			},
+			"serial_number_prefix": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Filter by the start of the serial number, ignoring case.",
+			},
+			"is_enrolled": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Filter by whether the device is enrolled.",
+			},
 			"devices": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -175,78 +201,93 @@ func (d *adeDevicesDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
-	var allDevices []client.ADEDevice
-	page := 1
-	
-	for {
-		params := url.Values{}
-		params.Add("page", fmt.Sprintf("%d", page))
-
-		if !data.BlueprintID.IsNull() {
-			params.Add("blueprint_id", data.BlueprintID.ValueString())
-		}
-		if !data.UserID.IsNull() {
-			params.Add("user_id", data.UserID.ValueString())
-		}
-		if !data.DEPAccount.IsNull() {
-			params.Add("dep_account", data.DEPAccount.ValueString())
-		}
-		if !data.DeviceFamily.IsNull() {
-			params.Add("device_family", data.DeviceFamily.ValueString())
-		}
-		if !data.Model.IsNull() {
-			params.Add("model", data.Model.ValueString())
-		}
-		if !data.OS.IsNull() {
-			params.Add("os", data.OS.ValueString())
-		}
-		if !data.ProfileStatus.IsNull() {
-			params.Add("profile_status", data.ProfileStatus.ValueString())
-		}
-		if !data.SerialNumber.IsNull() {
-			params.Add("serial_number", data.SerialNumber.ValueString())
-		}
-
-		path := "/api/v1/integrations/apple/ade/devices?" + params.Encode()
-		
-		type adeDevicesResponse struct {
-			Results []client.ADEDevice `json:"results"`
-			Next    string             `json:"next"`
-		}
-		var listResp adeDevicesResponse
-		err := d.client.DoRequest(ctx, "GET", path, nil, &listResp)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read ADE devices, got error: %s", err))
-			return
-		}
+	filter := adeDeviceFilterModel{
+		BlueprintID:   data.BlueprintID,
+		UserID:        data.UserID,
+		DEPAccount:    data.DEPAccount,
+		DeviceFamily:  data.DeviceFamily,
+		Model:         data.Model,
+		OS:            data.OS,
+		ProfileStatus: data.ProfileStatus,
+		SerialNumber:  data.SerialNumber,
+		SerialPrefix:  data.SerialPrefix,
+		IsEnrolled:    data.IsEnrolled,
+	}
 
-		allDevices = append(allDevices, listResp.Results...)
-		if listResp.Next == "" || len(listResp.Results) == 0 {
-			break
-		}
-		page++
+	allDevices, err := filter.list(ctx, d.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read ADE devices, got error: %s", err))
+		return
 	}
 
 	data.ID = types.StringValue("ade_devices")
 	data.Devices = make([]adeDeviceModel, 0, len(allDevices))
 	for _, device := range allDevices {
 		data.Devices = append(data.Devices, adeDeviceModel{
-			ID:            types.StringValue(device.ID),
-			SerialNumber:  types.StringValue(device.SerialNumber),
-			Model:         types.StringValue(device.Model),
-			Description:   types.StringValue(device.Description),
-			AssetTag:      types.StringValue(device.AssetTag),
-			Color:         types.StringValue(device.Color),
-			BlueprintID:   types.StringValue(device.BlueprintID),
-			UserID:        types.StringValue(device.UserID),
-			DEPAccount:    types.StringValue(device.DEPAccount),
-			DeviceFamily:  types.StringValue(device.DeviceFamily),
-			OS:            types.StringValue(device.OS),
-			ProfileStatus: types.StringValue(device.ProfileStatus),
-			IsEnrolled:    types.BoolValue(device.IsEnrolled),
+			ID:                  types.StringValue(device.ID),
+			SerialNumber:        types.StringValue(device.SerialNumber),
+			Model:               types.StringValue(device.Model),
+			Description:         types.StringValue(device.Description),
+			AssetTag:            types.StringValue(device.AssetTag),
+			Color:               types.StringValue(device.Color),
+			BlueprintID:         types.StringValue(device.BlueprintID),
+			UserID:              types.StringValue(device.UserID),
+			DEPAccount:          types.StringValue(device.DEPAccount),
+			DeviceFamily:        types.StringValue(device.DeviceFamily),
+			OS:                  types.StringValue(device.OS),
+			ProfileStatus:       types.StringValue(device.ProfileStatus),
+			IsEnrolled:          types.BoolValue(device.IsEnrolled),
 			UseBlueprintRouting: types.BoolValue(device.UseBlueprintRouting),
 		})
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// list returns every ADE device matching the filters. Filters supported by
+// the API are sent as query parameters, and the rest are applied here.
+func (f adeDeviceFilterModel) list(ctx context.Context, c *client.Client) ([]client.ADEDevice, error) {
+	devices, err := listADEDevices(ctx, c, f.params())
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]client.ADEDevice, 0, len(devices))
+	for _, device := range devices {
+		if f.matches(device) {
+			filtered = append(filtered, device)
+		}
+	}
+	return filtered, nil
+}
+
+// params returns the filters that the API supports as query parameters.
+func (f adeDeviceFilterModel) params() url.Values {
+	params := url.Values{}
+	for name, value := range map[string]types.String{
+		"blueprint_id":   f.BlueprintID,
+		"user_id":        f.UserID,
+		"dep_account":    f.DEPAccount,
+		"device_family":  f.DeviceFamily,
+		"model":          f.Model,
+		"os":             f.OS,
+		"profile_status": f.ProfileStatus,
+		"serial_number":  f.SerialNumber,
+	} {
+		if !value.IsNull() {
+			params.Add(name, value.ValueString())
+		}
+	}
+	return params
+}
+
+// matches applies the filters that the API does not support.
+func (f adeDeviceFilterModel) matches(device client.ADEDevice) bool {
+	if !f.IsEnrolled.IsNull() && device.IsEnrolled != f.IsEnrolled.ValueBool() {
+		return false
+	}
+	if !f.SerialPrefix.IsNull() && !strings.HasPrefix(strings.ToUpper(device.SerialNumber), strings.ToUpper(f.SerialPrefix.ValueString())) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestADEDeviceFilterList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("dep_account"); got != "Engineering" {
+			t.Errorf("Expected dep_account=Engineering, got %q", got)
+		}
+		if r.URL.Query().Has("is_enrolled") || r.URL.Query().Has("serial_number_prefix") {
+			t.Errorf("Expected client-side filters not to be sent, got %s", r.URL.RawQuery)
+		}
+		if r.URL.Query().Get("page") == "1" {
+			_, _ = w.Write([]byte(`{"next": "page2", "results": [
+				{"device_id": "1", "serial_number": "C02AAA", "is_enrolled": false},
+				{"device_id": "2", "serial_number": "C02BBB", "is_enrolled": true}
+			]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"next": "", "results": [
+			{"device_id": "3", "serial_number": "c02ccc", "is_enrolled": false},
+			{"device_id": "4", "serial_number": "F9XDDD", "is_enrolled": false}
+		]}`))
+	}))
+	defer server.Close()
+
+	filter := adeDeviceFilterModel{
+		DEPAccount:   types.StringValue("Engineering"),
+		SerialPrefix: types.StringValue("C02"),
+		IsEnrolled:   types.BoolValue(false),
+	}
+	devices, err := filter.list(context.Background(), client.NewClient(server.URL, "test-token"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(devices) != 2 || devices[0].ID != "1" || devices[1].ID != "3" {
+		t.Errorf("Expected devices 1 and 3, got %v", devices)
+	}
+}
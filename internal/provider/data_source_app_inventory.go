@@ -0,0 +1,287 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &appInventoryDataSource{}
+
+func NewAppInventoryDataSource() datasource.DataSource {
+	return &appInventoryDataSource{}
+}
+
+type appInventoryDataSource struct {
+	client *client.Client
+}
+
+type appInventoryDataSourceModel struct {
+	ID             types.String             `tfsdk:"id"`
+	BundleIDs      types.List               `tfsdk:"bundle_ids"`
+	NameRegex      types.String             `tfsdk:"name_regex"`
+	MinVersion     types.String             `tfsdk:"min_version"`
+	MaxVersion     types.String             `tfsdk:"max_version"`
+	BlueprintIDs   types.List               `tfsdk:"blueprint_ids"`
+	DeviceFamilies types.List               `tfsdk:"device_families"`
+	TotalDevices   types.Int64              `tfsdk:"total_devices"`
+	Versions       []appInventoryGroupModel `tfsdk:"versions"`
+}
+
+type appInventoryGroupModel struct {
+	BundleID    types.String              `tfsdk:"bundle_id"`
+	Name        types.String              `tfsdk:"name"`
+	Version     types.String              `tfsdk:"version"`
+	DeviceCount types.Int64               `tfsdk:"device_count"`
+	Devices     []appInventoryDeviceModel `tfsdk:"devices"`
+}
+
+type appInventoryDeviceModel struct {
+	DeviceID     types.String `tfsdk:"device_id"`
+	DeviceName   types.String `tfsdk:"device_name"`
+	SerialNumber types.String `tfsdk:"serial_number"`
+	Path         types.String `tfsdk:"path"`
+}
+
+func (d *appInventoryDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_inventory"
+}
+
+func (d *appInventoryDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Summarize the applications installed across the fleet from Prism, grouped by bundle ID and version with the devices running each version. Versions are compared segment by segment, so `1.10` is newer than `1.9` and Apple-style versions such as `14.2.1 (23C71)` are ordered by their build number last.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"bundle_ids": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Only include applications with these bundle IDs, such as `com.google.Chrome`.",
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only include applications whose name matches this regular expression.",
+			},
+			"min_version": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only include versions equal to or newer than this version.",
+			},
+			"max_version": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only include versions older than this version. For example, `max_version = \"120\"` finds every version before 120.",
+			},
+			"blueprint_ids": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Only include devices in these blueprints.",
+			},
+			"device_families": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Only include these device families, such as `Mac`.",
+			},
+			"total_devices": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of distinct devices across all returned versions.",
+			},
+			"versions": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "One entry per bundle ID and version, ordered by bundle ID and then newest version first.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"bundle_id": schema.StringAttribute{Computed: true},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The application name reported by the first device.",
+						},
+						"version": schema.StringAttribute{Computed: true},
+						"device_count": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The number of devices running this version.",
+						},
+						"devices": schema.ListNestedAttribute{
+							Computed:            true,
+							MarkdownDescription: "The devices running this version.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"device_id":     schema.StringAttribute{Computed: true},
+									"device_name":   schema.StringAttribute{Computed: true},
+									"serial_number": schema.StringAttribute{Computed: true},
+									"path": schema.StringAttribute{
+										Computed:            true,
+										MarkdownDescription: "Where the application is installed on the device.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *appInventoryDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *appInventoryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data appInventoryDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var filter appInventoryFilter
+	filter.minVersion = data.MinVersion.ValueString()
+	filter.maxVersion = data.MaxVersion.ValueString()
+	if !data.BundleIDs.IsNull() {
+		var bundleIDs []string
+		resp.Diagnostics.Append(data.BundleIDs.ElementsAs(ctx, &bundleIDs, false)...)
+		filter.bundleIDs = map[string]bool{}
+		for _, bundleID := range bundleIDs {
+			filter.bundleIDs[bundleID] = true
+		}
+	}
+	if !data.NameRegex.IsNull() {
+		re, err := regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid Name Regex", fmt.Sprintf("Unable to compile %q, got error: %s", data.NameRegex.ValueString(), err))
+		}
+		filter.nameRegex = re
+	}
+
+	query, diags := newPrismQuery(ctx, "apps", data.BlueprintIDs, data.DeviceFamilies, types.ObjectNull(nil), types.StringNull())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Bundle IDs are narrowed down on the server; the rest of the filters
+	// apply to the returned rows.
+	if filter.bundleIDs != nil {
+		bundleIDs := make([]string, 0, len(filter.bundleIDs))
+		for bundleID := range filter.bundleIDs {
+			bundleIDs = append(bundleIDs, bundleID)
+		}
+		sort.Strings(bundleIDs)
+		encoded, _ := json.Marshal(map[string]any{"bundle_id": map[string]any{"in": bundleIDs}})
+		query.Filter = string(encoded)
+	}
+
+	apps, err := readPrism[client.PrismApp](ctx, d.client, query, types.Int64Null(), types.Int64Null())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prism apps, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue("app_inventory")
+	data.Versions, data.TotalDevices = groupAppInventory(apps, filter)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+type appInventoryFilter struct {
+	bundleIDs  map[string]bool
+	nameRegex  *regexp.Regexp
+	minVersion string
+	maxVersion string
+}
+
+func (f appInventoryFilter) match(app client.PrismApp) bool {
+	if f.bundleIDs != nil && !f.bundleIDs[app.BundleID] {
+		return false
+	}
+	if f.nameRegex != nil && !f.nameRegex.MatchString(app.Name) {
+		return false
+	}
+	if f.minVersion != "" && compareVersions(app.Version, f.minVersion) < 0 {
+		return false
+	}
+	if f.maxVersion != "" && compareVersions(app.Version, f.maxVersion) >= 0 {
+		return false
+	}
+	return true
+}
+
+// groupAppInventory groups the matching apps by bundle ID and version and
+// counts the distinct devices across all groups.
+func groupAppInventory(apps []client.PrismApp, filter appInventoryFilter) ([]appInventoryGroupModel, types.Int64) {
+	type key struct{ bundleID, version string }
+	groups := map[key]*appInventoryGroupModel{}
+	groupDevices := map[key]map[string]bool{}
+	devices := map[string]bool{}
+
+	for _, app := range apps {
+		if !filter.match(app) {
+			continue
+		}
+
+		k := key{app.BundleID, app.Version}
+		group, ok := groups[k]
+		if !ok {
+			group = &appInventoryGroupModel{
+				BundleID: types.StringValue(app.BundleID),
+				Name:     types.StringValue(app.Name),
+				Version:  types.StringValue(app.Version),
+				Devices:  []appInventoryDeviceModel{},
+			}
+			groups[k] = group
+			groupDevices[k] = map[string]bool{}
+		}
+
+		devices[app.DeviceID] = true
+		if groupDevices[k][app.DeviceID] {
+			continue
+		}
+		groupDevices[k][app.DeviceID] = true
+		group.Devices = append(group.Devices, appInventoryDeviceModel{
+			DeviceID:     types.StringValue(app.DeviceID),
+			DeviceName:   types.StringValue(app.DeviceName),
+			SerialNumber: types.StringValue(app.SerialNumber),
+			Path:         types.StringValue(app.Path),
+		})
+	}
+
+	versions := make([]appInventoryGroupModel, 0, len(groups))
+	for _, group := range groups {
+		group.DeviceCount = types.Int64Value(int64(len(group.Devices)))
+		sort.Slice(group.Devices, func(i, j int) bool {
+			return group.Devices[i].DeviceName.ValueString() < group.Devices[j].DeviceName.ValueString()
+		})
+		versions = append(versions, *group)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		if a, b := versions[i].BundleID.ValueString(), versions[j].BundleID.ValueString(); a != b {
+			return a < b
+		}
+		if c := compareVersions(versions[i].Version.ValueString(), versions[j].Version.ValueString()); c != 0 {
+			return c > 0
+		}
+		return versions[i].Version.ValueString() < versions[j].Version.ValueString()
+	})
+
+	return versions, types.Int64Value(int64(len(devices)))
+}
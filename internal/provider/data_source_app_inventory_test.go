@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestGroupAppInventory(t *testing.T) {
+	apps := []client.PrismApp{
+		{DeviceID: "1", DeviceName: "Mac-1", BundleID: "com.google.Chrome", Name: "Google Chrome", Version: "120.0.6099.71"},
+		{DeviceID: "2", DeviceName: "Mac-2", BundleID: "com.google.Chrome", Name: "Google Chrome", Version: "119.0.6045.199"},
+		{DeviceID: "3", DeviceName: "Mac-3", BundleID: "com.google.Chrome", Name: "Google Chrome", Version: "120.0.6099.71"},
+		{DeviceID: "3", DeviceName: "Mac-3", BundleID: "com.google.Chrome", Name: "Google Chrome", Version: "120.0.6099.71", Path: "/Users/me/Applications/Google Chrome.app"},
+		{DeviceID: "4", DeviceName: "Mac-4", BundleID: "com.google.Chrome", Name: "Google Chrome", Version: "99.0.4844.51"},
+		{DeviceID: "1", DeviceName: "Mac-1", BundleID: "com.apple.Safari", Name: "Safari", Version: "17.2 (19617.1.17.11.12)"},
+	}
+
+	versions, total := groupAppInventory(apps, appInventoryFilter{})
+	if total.ValueInt64() != 4 || len(versions) != 4 {
+		t.Fatalf("Expected 4 versions on 4 devices, got %d on %d", len(versions), total.ValueInt64())
+	}
+	if versions[0].BundleID.ValueString() != "com.apple.Safari" {
+		t.Errorf("Expected groups ordered by bundle ID, got %s first", versions[0].BundleID)
+	}
+	chrome := versions[1]
+	if chrome.Version.ValueString() != "120.0.6099.71" || chrome.DeviceCount.ValueInt64() != 2 || len(chrome.Devices) != 2 {
+		t.Errorf("Expected the newest Chrome on 2 devices first, got %v", chrome)
+	}
+	if versions[3].Version.ValueString() != "99.0.4844.51" {
+		t.Errorf("Expected versions compared numerically, got %s last", versions[3].Version)
+	}
+
+	outdated, total := groupAppInventory(apps, appInventoryFilter{bundleIDs: map[string]bool{"com.google.Chrome": true}, maxVersion: "120"})
+	if len(outdated) != 2 || total.ValueInt64() != 2 {
+		t.Errorf("Expected 2 outdated Chrome versions on 2 devices, got %v", outdated)
+	}
+
+	current, _ := groupAppInventory(apps, appInventoryFilter{minVersion: "17.2 (19617.1.17.11.12)", maxVersion: "99"})
+	if len(current) != 1 || current[0].BundleID.ValueString() != "com.apple.Safari" {
+		t.Errorf("Expected only Safari between 17.2 and 99, got %v", current)
+	}
+}
+
+func TestAppInventoryRead(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("filter"); got != `{"bundle_id":{"in":["com.google.Chrome"]}}` {
+			t.Errorf("Expected the bundle IDs to be filtered on the server, got %s", got)
+		}
+		_, _ = w.Write([]byte(`{"data": [{"device_id": "1", "bundle_id": "com.google.Chrome", "version": "120.0"}]}`))
+	}))
+	defer server.Close()
+
+	query := prismQuery{Category: "apps", Filter: `{"bundle_id":{"in":["com.google.Chrome"]}}`}
+	apps, err := readPrism[client.PrismApp](context.Background(), client.NewClient(server.URL, "test-token"), query, types.Int64Null(), types.Int64Null())
+	if err != nil || len(apps) != 1 {
+		t.Fatalf("Expected one app, got %v, %v", apps, err)
+	}
+}
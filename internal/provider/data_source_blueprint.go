@@ -23,6 +23,7 @@ type blueprintDataSource struct {
 type blueprintSingleDataSourceModel struct {
 	ID                   types.String `tfsdk:"id"`
 	Name                 types.String `tfsdk:"name"`
+	NameRegex            types.String `tfsdk:"name_regex"`
 	Description          types.String `tfsdk:"description"`
 	Type                 types.String `tfsdk:"type"`
 	EnrollmentCode       types.String `tfsdk:"enrollment_code"`
@@ -35,14 +36,21 @@ func (d *blueprintDataSource) Metadata(ctx context.Context, req datasource.Metad
 
 func (d *blueprintDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Get details for a specific blueprint.",
+		MarkdownDescription: "Get details for a specific blueprint, looked up by ID, by exact name or by name regular expression. Exactly one of `id`, `name` and `name_regex` must be set, and the lookup fails unless exactly one blueprint matches.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Required:            true,
+				Optional:            true,
+				Computed:            true,
 				MarkdownDescription: "The unique identifier for the Blueprint.",
 			},
 			"name": schema.StringAttribute{
-				Computed: true,
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The exact name of the Blueprint.",
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "A regular expression matching the name of the Blueprint.",
 			},
 			"description": schema.StringAttribute{
 				Computed: true,
@@ -74,13 +82,29 @@ func (d *blueprintDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
-	var blueprint client.Blueprint
-	err := d.client.DoRequest(ctx, "GET", "/api/v1/blueprints/"+data.ID.ValueString(), nil, &blueprint)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read blueprint, got error: %s", err))
+	lookup, diags := newNameLookup(data.ID, data.Name, data.NameRegex)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	var blueprint client.Blueprint
+	if lookup != nil {
+		items := resultsPages[client.Blueprint](ctx, d.client, "/api/v1/blueprints", lookup.params())
+		blueprint, diags = findOne(lookup, "Blueprint", items, func(b client.Blueprint) string { return b.Name })
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.ID = types.StringValue(blueprint.ID)
+	} else {
+		err := d.client.DoRequest(ctx, "GET", "/api/v1/blueprints/"+data.ID.ValueString(), nil, &blueprint)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read blueprint, got error: %s", err))
+			return
+		}
+	}
+
 	data.Name = types.StringValue(blueprint.Name)
 	data.Description = types.StringValue(blueprint.Description)
 	data.Type = types.StringValue(blueprint.Type)
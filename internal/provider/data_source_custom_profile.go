@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &customProfileDataSource{}
+
+func NewCustomProfileDataSource() datasource.DataSource {
+	return &customProfileDataSource{}
+}
+
+type customProfileDataSource struct {
+	client *client.Client
+}
+
+type customProfileSingleDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	NameRegex     types.String `tfsdk:"name_regex"`
+	Active        types.Bool   `tfsdk:"active"`
+	MDMIdentifier types.String `tfsdk:"mdm_identifier"`
+	RunsOnMac     types.Bool   `tfsdk:"runs_on_mac"`
+	RunsOnIPhone  types.Bool   `tfsdk:"runs_on_iphone"`
+	RunsOnIPad    types.Bool   `tfsdk:"runs_on_ipad"`
+	RunsOnTV      types.Bool   `tfsdk:"runs_on_tv"`
+	RunsOnVision  types.Bool   `tfsdk:"runs_on_vision"`
+}
+
+func (d *customProfileDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_custom_profile"
+}
+
+func (d *customProfileDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Get details for a specific custom profile, looked up by ID, by exact name or by name regular expression. Exactly one of `id`, `name` and `name_regex` must be set, and the lookup fails unless exactly one custom profile matches.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the Custom Profile.",
+			},
+			"name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The exact name of the Custom Profile.",
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "A regular expression matching the name of the Custom Profile.",
+			},
+			"active": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the Custom Profile is active.",
+			},
+			"mdm_identifier": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The payload identifier of the profile.",
+			},
+			"runs_on_mac": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the profile runs on macOS.",
+			},
+			"runs_on_iphone": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the profile runs on iOS.",
+			},
+			"runs_on_ipad": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the profile runs on iPadOS.",
+			},
+			"runs_on_tv": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the profile runs on tvOS.",
+			},
+			"runs_on_vision": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the profile runs on visionOS.",
+			},
+		},
+	}
+}
+
+func (d *customProfileDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.client = req.ProviderData.(*client.Client)
+}
+
+func (d *customProfileDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data customProfileSingleDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	lookup, diags := newNameLookup(data.ID, data.Name, data.NameRegex)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var profile client.CustomProfile
+	if lookup != nil {
+		items := resultsPages[client.CustomProfile](ctx, d.client, "/api/v1/library/custom-profiles", lookup.params())
+		profile, diags = findOne(lookup, "Custom Profile", items, func(p client.CustomProfile) string { return p.Name })
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.ID = types.StringValue(profile.ID)
+	} else {
+		err := d.client.DoRequest(ctx, "GET", "/api/v1/library/custom-profiles/"+data.ID.ValueString(), nil, &profile)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read custom profile, got error: %s", err))
+			return
+		}
+	}
+
+	data.Name = types.StringValue(profile.Name)
+	data.Active = types.BoolValue(profile.Active)
+	data.MDMIdentifier = types.StringValue(profile.MDMIdentifier)
+	data.RunsOnMac = types.BoolValue(profile.RunsOnMac)
+	data.RunsOnIPhone = types.BoolValue(profile.RunsOnIPhone)
+	data.RunsOnIPad = types.BoolValue(profile.RunsOnIPad)
+	data.RunsOnTV = types.BoolValue(profile.RunsOnTV)
+	data.RunsOnVision = types.BoolValue(profile.RunsOnVision)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
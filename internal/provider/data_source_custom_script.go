@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &customScriptDataSource{}
+
+func NewCustomScriptDataSource() datasource.DataSource {
+	return &customScriptDataSource{}
+}
+
+type customScriptDataSource struct {
+	client *client.Client
+}
+
+type customScriptSingleDataSourceModel struct {
+	ID                    types.String `tfsdk:"id"`
+	Name                  types.String `tfsdk:"name"`
+	NameRegex             types.String `tfsdk:"name_regex"`
+	Active                types.Bool   `tfsdk:"active"`
+	ExecutionFrequency    types.String `tfsdk:"execution_frequency"`
+	Restart               types.Bool   `tfsdk:"restart"`
+	Script                types.String `tfsdk:"script"`
+	RemediationScript     types.String `tfsdk:"remediation_script"`
+	ShowInSelfService     types.Bool   `tfsdk:"show_in_self_service"`
+	SelfServiceCategoryID types.String `tfsdk:"self_service_category_id"`
+}
+
+func (d *customScriptDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_custom_script"
+}
+
+func (d *customScriptDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Get details for a specific custom script, looked up by ID, by exact name or by name regular expression. Exactly one of `id`, `name` and `name_regex` must be set, and the lookup fails unless exactly one custom script matches.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the Custom Script.",
+			},
+			"name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The exact name of the Custom Script.",
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "A regular expression matching the name of the Custom Script.",
+			},
+			"active": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the Custom Script is active.",
+			},
+			"execution_frequency": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The frequency at which the script is enforced.",
+			},
+			"restart": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the computer restarts after the script succeeds.",
+			},
+			"script": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The script content.",
+			},
+			"remediation_script": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The script that runs when the primary script fails.",
+			},
+			"show_in_self_service": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the script is available in Self Service.",
+			},
+			"self_service_category_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The UUID of the Self Service category of the script.",
+			},
+		},
+	}
+}
+
+func (d *customScriptDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.client = req.ProviderData.(*client.Client)
+}
+
+func (d *customScriptDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data customScriptSingleDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	lookup, diags := newNameLookup(data.ID, data.Name, data.NameRegex)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var script client.CustomScript
+	if lookup != nil {
+		items := resultsPages[client.CustomScript](ctx, d.client, "/api/v1/library/custom-scripts", lookup.params())
+		script, diags = findOne(lookup, "Custom Script", items, func(s client.CustomScript) string { return s.Name })
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.ID = types.StringValue(script.ID)
+	} else {
+		err := d.client.DoRequest(ctx, "GET", "/api/v1/library/custom-scripts/"+data.ID.ValueString(), nil, &script)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read custom script, got error: %s", err))
+			return
+		}
+	}
+
+	data.Name = types.StringValue(script.Name)
+	data.Active = types.BoolValue(script.Active)
+	data.ExecutionFrequency = types.StringValue(script.ExecutionFrequency)
+	data.Restart = types.BoolValue(script.Restart)
+	data.Script = types.StringValue(script.Script)
+	data.RemediationScript = types.StringValue(script.RemediationScript)
+	data.ShowInSelfService = types.BoolValue(script.ShowInSelfService)
+	data.SelfServiceCategoryID = types.StringValue(script.SelfServiceCategoryID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
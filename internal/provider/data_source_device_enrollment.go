@@ -0,0 +1,265 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &deviceEnrollmentDataSource{}
+
+const (
+	defaultEnrollmentTimeout      = 30 * time.Minute
+	defaultEnrollmentPollInterval = 30 * time.Second
+)
+
+func NewDeviceEnrollmentDataSource() datasource.DataSource {
+	return &deviceEnrollmentDataSource{
+		after: time.After,
+	}
+}
+
+type deviceEnrollmentDataSource struct {
+	client *client.Client
+	after  func(time.Duration) <-chan time.Time
+}
+
+type deviceEnrollmentDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	SerialNumber  types.String `tfsdk:"serial_number"`
+	Timeout       types.String `tfsdk:"timeout"`
+	PollInterval  types.String `tfsdk:"poll_interval"`
+	DeviceID      types.String `tfsdk:"device_id"`
+	ADEDeviceID   types.String `tfsdk:"ade_device_id"`
+	ProfileStatus types.String `tfsdk:"profile_status"`
+	DeviceName    types.String `tfsdk:"device_name"`
+	Model         types.String `tfsdk:"model"`
+	Platform      types.String `tfsdk:"platform"`
+	OSVersion     types.String `tfsdk:"os_version"`
+	BlueprintID   types.String `tfsdk:"blueprint_id"`
+	UserID        types.String `tfsdk:"user_id"`
+	AssetTag      types.String `tfsdk:"asset_tag"`
+	LastCheckIn   types.String `tfsdk:"last_check_in"`
+}
+
+func (d *deviceEnrollmentDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_enrollment"
+}
+
+func (d *deviceEnrollmentDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Waits for an Automated Device Enrollment (ADE) device to enroll and check in, then returns the enrolled device. The data source first polls the ADE device until it reports `is_enrolled`, then polls the device inventory until a device with the serial number has checked in. Use it after `iru_ade_device` to order user assignment and actions after enrollment.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the enrolled Device.",
+			},
+			"serial_number": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The serial number of the Device to wait for.",
+			},
+			"timeout": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "How long to wait for the device to enroll and check in, as a Go duration such as `45m`. Defaults to `30m`.",
+			},
+			"poll_interval": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "How long to wait between checks, as a Go duration such as `10s`. Defaults to `30s`.",
+			},
+			"device_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the enrolled Device.",
+			},
+			"ade_device_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the ADE Device.",
+			},
+			"profile_status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ADE profile status of the Device.",
+			},
+			"device_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The name of the Device.",
+			},
+			"model": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The model of the Device.",
+			},
+			"platform": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The platform of the Device.",
+			},
+			"os_version": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The OS version of the Device.",
+			},
+			"blueprint_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the blueprint assigned to the Device.",
+			},
+			"user_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the user assigned to the Device.",
+			},
+			"asset_tag": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The asset tag of the Device.",
+			},
+			"last_check_in": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The time the Device last checked in.",
+			},
+		},
+	}
+}
+
+func (d *deviceEnrollmentDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.client = req.ProviderData.(*client.Client)
+}
+
+func (d *deviceEnrollmentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data deviceEnrollmentDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout := defaultEnrollmentTimeout
+	if !data.Timeout.IsNull() {
+		parsed, err := time.ParseDuration(data.Timeout.ValueString())
+		if err != nil || parsed <= 0 {
+			resp.Diagnostics.AddError("Invalid Timeout", fmt.Sprintf("`timeout` must be a positive duration such as `30m`, got %q.", data.Timeout.ValueString()))
+			return
+		}
+		timeout = parsed
+	}
+
+	interval := defaultEnrollmentPollInterval
+	if !data.PollInterval.IsNull() {
+		parsed, err := time.ParseDuration(data.PollInterval.ValueString())
+		if err != nil || parsed <= 0 {
+			resp.Diagnostics.AddError("Invalid Poll Interval", fmt.Sprintf("`poll_interval` must be a positive duration such as `30s`, got %q.", data.PollInterval.ValueString()))
+			return
+		}
+		interval = parsed
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	adeDevice, device, err := d.waitForEnrollment(ctx, data.SerialNumber.ValueString(), interval)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			resp.Diagnostics.AddError("Enrollment Timeout", fmt.Sprintf("Device %s did not enroll and check in within %s: %s", data.SerialNumber.ValueString(), timeout, err))
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to wait for device enrollment, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue(device.ID)
+	data.DeviceID = types.StringValue(device.ID)
+	data.ADEDeviceID = types.StringValue(adeDevice.ID)
+	data.ProfileStatus = types.StringValue(adeDevice.ProfileStatus)
+	data.DeviceName = types.StringValue(device.DeviceName)
+	data.Model = types.StringValue(device.Model)
+	data.Platform = types.StringValue(device.Platform)
+	data.OSVersion = types.StringValue(device.OSVersion)
+	data.BlueprintID = types.StringValue(device.BlueprintID)
+	data.UserID = types.StringValue(device.UserID)
+	data.AssetTag = types.StringValue(device.AssetTag)
+	data.LastCheckIn = types.StringValue(device.LastCheckIn)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// waitForEnrollment polls until the ADE device with the serial number reports
+// that it is enrolled and a device with that serial number has checked in.
+// It returns the context error, annotated with the last state seen, when ctx
+// ends first.
+func (d *deviceEnrollmentDataSource) waitForEnrollment(ctx context.Context, serial string, interval time.Duration) (client.ADEDevice, client.Device, error) {
+	state := "ADE device not found"
+	for {
+		adeDevice, found, err := d.findADEDevice(ctx, serial)
+		if err != nil {
+			return client.ADEDevice{}, client.Device{}, err
+		}
+
+		if found {
+			state = fmt.Sprintf("ADE profile status %q, enrolled: %t", adeDevice.ProfileStatus, adeDevice.IsEnrolled)
+		}
+
+		if found && adeDevice.IsEnrolled {
+			device, checkedIn, err := d.findCheckedInDevice(ctx, serial)
+			if err != nil {
+				return client.ADEDevice{}, client.Device{}, err
+			}
+			if checkedIn {
+				return adeDevice, device, nil
+			}
+			state += ", device has not checked in"
+		}
+
+		tflog.Debug(ctx, "Waiting for device enrollment", map[string]interface{}{
+			"serial_number": serial,
+			"state":         state,
+		})
+
+		select {
+		case <-ctx.Done():
+			return client.ADEDevice{}, client.Device{}, fmt.Errorf("%s: %w", state, ctx.Err())
+		case <-d.after(interval):
+		}
+	}
+}
+
+func (d *deviceEnrollmentDataSource) findADEDevice(ctx context.Context, serial string) (client.ADEDevice, bool, error) {
+	params := url.Values{}
+	params.Add("serial_number", serial)
+
+	var listResp struct {
+		Results []client.ADEDevice `json:"results"`
+	}
+	err := d.client.DoRequest(ctx, "GET", "/api/v1/integrations/apple/ade/devices?"+params.Encode(), nil, &listResp)
+	if err != nil {
+		return client.ADEDevice{}, false, err
+	}
+
+	for _, device := range listResp.Results {
+		if strings.EqualFold(device.SerialNumber, serial) {
+			return device, true, nil
+		}
+	}
+	return client.ADEDevice{}, false, nil
+}
+
+func (d *deviceEnrollmentDataSource) findCheckedInDevice(ctx context.Context, serial string) (client.Device, bool, error) {
+	params := url.Values{}
+	params.Add("serial_number", serial)
+
+	var devices []client.Device
+	err := d.client.DoRequest(ctx, "GET", "/api/v1/devices?"+params.Encode(), nil, &devices)
+	if err != nil {
+		return client.Device{}, false, err
+	}
+
+	for _, device := range devices {
+		if strings.EqualFold(device.SerialNumber, serial) && device.LastCheckIn != "" {
+			return device, true, nil
+		}
+	}
+	return client.Device{}, false, nil
+}
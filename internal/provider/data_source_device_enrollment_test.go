@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+)
+
+func TestDeviceEnrollmentWait(t *testing.T) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("serial_number") != "C02ABC" {
+			t.Errorf("Expected serial_number=C02ABC, got %s", r.URL.RawQuery)
+		}
+		switch r.URL.Path {
+		case "/api/v1/integrations/apple/ade/devices":
+			polls++
+			enrolled := "false"
+			if polls >= 2 {
+				enrolled = "true"
+			}
+			_, _ = w.Write([]byte(`{"results": [{"device_id": "ade-1", "serial_number": "C02ABC", "profile_status": "pushed", "is_enrolled": ` + enrolled + `}]}`))
+		case "/api/v1/devices":
+			checkIn := ""
+			if polls >= 3 {
+				checkIn = "2026-01-01T00:00:00Z"
+			}
+			_, _ = w.Write([]byte(`[{"device_id": "dev-1", "serial_number": "C02ABC", "last_check_in": "` + checkIn + `"}]`))
+		default:
+			t.Errorf("Unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	immediate := func(time.Duration) <-chan time.Time {
+		ch := make(chan time.Time, 1)
+		ch <- time.Now()
+		return ch
+	}
+
+	t.Run("waits for enrollment and check-in", func(t *testing.T) {
+		polls = 0
+		d := &deviceEnrollmentDataSource{client: client.NewClient(server.URL, "test-token"), after: immediate}
+		adeDevice, device, err := d.waitForEnrollment(context.Background(), "C02ABC", time.Second)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if adeDevice.ID != "ade-1" || device.ID != "dev-1" {
+			t.Errorf("Unexpected devices: %v, %v", adeDevice, device)
+		}
+		if polls != 3 {
+			t.Errorf("Expected 3 ADE polls, got %d", polls)
+		}
+	})
+
+	t.Run("stops when the context ends", func(t *testing.T) {
+		polls = 0
+		never := func(time.Duration) <-chan time.Time { return make(chan time.Time) }
+		d := &deviceEnrollmentDataSource{client: client.NewClient(server.URL, "test-token"), after: never}
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, _, err := d.waitForEnrollment(ctx, "C02ABC", time.Second)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("Expected deadline exceeded, got %v", err)
+		}
+	})
+}
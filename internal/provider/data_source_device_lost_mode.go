@@ -34,7 +34,7 @@ func (d *deviceLostModeDataSource) Metadata(ctx context.Context, req datasource.
 
 func (d *deviceLostModeDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Get Lost Mode details for a specific device. To manage Lost Mode, use `iru_device_action_enable_lost_mode`, `iru_device_action_disable_lost_mode` (standard unlock), or `iru_device_action_cancel_lost_mode` (error recovery).",
+		MarkdownDescription: "Get Lost Mode details for a specific device. To manage Lost Mode declaratively, use the `iru_device_lost_mode` resource, or use `iru_device_action_enable_lost_mode`, `iru_device_action_disable_lost_mode` (standard unlock), or `iru_device_action_cancel_lost_mode` (error recovery).",
 		Attributes: map[string]schema.Attribute{
 			"device_id": schema.StringAttribute{
 				Required:            true,
@@ -70,12 +70,7 @@ func (d *deviceLostModeDataSource) Read(ctx context.Context, req datasource.Read
 		return
 	}
 
-	var lostMode struct {
-		Status      string `json:"status"`
-		Message     string `json:"message"`
-		PhoneNumber string `json:"phone_number"`
-		Footnote    string `json:"footnote"`
-	}
+	var lostMode client.DeviceLostMode
 	err := d.client.DoRequest(ctx, "GET", fmt.Sprintf("/api/v1/devices/%s/details/lostmode", data.DeviceID.ValueString()), nil, &lostMode)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read device lost mode details, got error: %s", err))
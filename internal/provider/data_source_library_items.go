@@ -0,0 +1,288 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &libraryItemsDataSource{}
+
+// libraryItemTypes lists the library item types of iru_library_items in the
+// order their results are returned.
+var libraryItemTypes = []string{"custom_app", "custom_profile", "custom_script", "in_house_app"}
+
+func NewLibraryItemsDataSource() datasource.DataSource {
+	return &libraryItemsDataSource{}
+}
+
+type libraryItemsDataSource struct {
+	client *client.Client
+}
+
+type libraryItemsDataSourceModel struct {
+	ID            types.String       `tfsdk:"id"`
+	Types         []types.String     `tfsdk:"types"`
+	Name          types.String       `tfsdk:"name"`
+	NameRegex     types.String       `tfsdk:"name_regex"`
+	Active        types.Bool         `tfsdk:"active"`
+	ExactlyOne    types.Bool         `tfsdk:"exactly_one"`
+	LibraryItemID types.String       `tfsdk:"library_item_id"`
+	LibraryItems  []libraryItemModel `tfsdk:"library_items"`
+}
+
+type libraryItemModel struct {
+	ID           types.String `tfsdk:"id"`
+	Type         types.String `tfsdk:"type"`
+	Name         types.String `tfsdk:"name"`
+	Active       types.Bool   `tfsdk:"active"`
+	RunsOnMac    types.Bool   `tfsdk:"runs_on_mac"`
+	RunsOnIPhone types.Bool   `tfsdk:"runs_on_iphone"`
+	RunsOnIPad   types.Bool   `tfsdk:"runs_on_ipad"`
+	RunsOnTV     types.Bool   `tfsdk:"runs_on_tv"`
+	RunsOnVision types.Bool   `tfsdk:"runs_on_vision"`
+}
+
+func (d *libraryItemsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_library_items"
+}
+
+func (d *libraryItemsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists Custom Apps, Custom Profiles, Custom Scripts and In-House Apps as one catalog of library items. With `exactly_one`, the data source fails unless exactly one library item matches, and its ID can be passed to `iru_blueprint_library_item` directly.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"types": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Only list library items of these types: `custom_app`, `custom_profile`, `custom_script` or `in_house_app`. Defaults to every type.",
+			},
+			"name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list library items with exactly this name.",
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list library items whose name matches this regular expression.",
+			},
+			"active": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list library items that are active (`true`) or inactive (`false`).",
+			},
+			"exactly_one": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Fail unless exactly one library item matches the filters.",
+			},
+			"library_item_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the library item when exactly one library item matches the filters, and null otherwise.",
+			},
+			"library_items": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The unique identifier for the Library Item.",
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The type of the Library Item: `custom_app`, `custom_profile`, `custom_script` or `in_house_app`.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the Library Item.",
+						},
+						"active": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the Library Item is active.",
+						},
+						"runs_on_mac": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the Library Item runs on macOS.",
+						},
+						"runs_on_iphone": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the Library Item runs on iOS.",
+						},
+						"runs_on_ipad": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the Library Item runs on iPadOS.",
+						},
+						"runs_on_tv": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the Library Item runs on tvOS.",
+						},
+						"runs_on_vision": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the Library Item runs on visionOS.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *libraryItemsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.client = req.ProviderData.(*client.Client)
+}
+
+func (d *libraryItemsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data libraryItemsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	wanted := libraryItemTypes
+	if data.Types != nil {
+		wanted = nil
+		for _, t := range data.Types {
+			if !slices.Contains(libraryItemTypes, t.ValueString()) {
+				resp.Diagnostics.AddAttributeError(path.Root("types"), "Invalid Library Item Type", fmt.Sprintf("Library item types must be one of %s, got %q.", strings.Join(libraryItemTypes, ", "), t.ValueString()))
+				continue
+			}
+			wanted = append(wanted, t.ValueString())
+		}
+	}
+
+	var nameRegex *regexp.Regexp
+	if !data.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid Name Regex", fmt.Sprintf("Unable to compile %q, got error: %s", data.NameRegex.ValueString(), err))
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	items, err := listLibraryItems(ctx, d.client, wanted)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read library items, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue("library_items")
+	data.LibraryItems = make([]libraryItemModel, 0, len(items))
+	for _, item := range items {
+		if !data.Name.IsNull() && item.Name.ValueString() != data.Name.ValueString() {
+			continue
+		}
+		if nameRegex != nil && !nameRegex.MatchString(item.Name.ValueString()) {
+			continue
+		}
+		if !data.Active.IsNull() && item.Active.ValueBool() != data.Active.ValueBool() {
+			continue
+		}
+		data.LibraryItems = append(data.LibraryItems, item)
+	}
+
+	if data.ExactlyOne.ValueBool() && len(data.LibraryItems) != 1 {
+		matches := make([]string, 0, len(data.LibraryItems))
+		for _, item := range data.LibraryItems {
+			matches = append(matches, fmt.Sprintf("%s %q (%s)", item.Type.ValueString(), item.Name.ValueString(), item.ID.ValueString()))
+		}
+		if len(matches) == 0 {
+			resp.Diagnostics.AddError("Library Item Not Found", "No library item matches the filters.")
+		} else {
+			resp.Diagnostics.AddError("Ambiguous Library Item", fmt.Sprintf("Expected exactly one library item to match the filters, got %d: %s", len(matches), strings.Join(matches, ", ")))
+		}
+		return
+	}
+
+	data.LibraryItemID = types.StringNull()
+	if len(data.LibraryItems) == 1 {
+		data.LibraryItemID = data.LibraryItems[0].ID
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// listLibraryItems fetches the library items of the given types concurrently
+// and returns them grouped by type, in the order of libraryItemTypes.
+func listLibraryItems(ctx context.Context, c *client.Client, itemTypes []string) ([]libraryItemModel, error) {
+	results := make([][]libraryItemModel, len(libraryItemTypes))
+	errs := make([]error, len(libraryItemTypes))
+
+	forEachConcurrently(len(libraryItemTypes), len(libraryItemTypes), func(i int) {
+		itemType := libraryItemTypes[i]
+		if !slices.Contains(itemTypes, itemType) {
+			return
+		}
+		results[i], errs[i] = listLibraryItemsOfType(ctx, c, itemType)
+	})
+
+	var items []libraryItemModel
+	for i := range libraryItemTypes {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		items = append(items, results[i]...)
+	}
+	return items, nil
+}
+
+func listLibraryItemsOfType(ctx context.Context, c *client.Client, itemType string) ([]libraryItemModel, error) {
+	var items []libraryItemModel
+	add := func(id, name string, active, mac, iphone, ipad, tv, vision bool) {
+		items = append(items, libraryItemModel{
+			ID:           types.StringValue(id),
+			Type:         types.StringValue(itemType),
+			Name:         types.StringValue(name),
+			Active:       types.BoolValue(active),
+			RunsOnMac:    types.BoolValue(mac),
+			RunsOnIPhone: types.BoolValue(iphone),
+			RunsOnIPad:   types.BoolValue(ipad),
+			RunsOnTV:     types.BoolValue(tv),
+			RunsOnVision: types.BoolValue(vision),
+		})
+	}
+
+	switch itemType {
+	case "custom_app":
+		for app, err := range resultsPages[client.CustomApp](ctx, c, "/api/v1/library/custom-apps", nil) {
+			if err != nil {
+				return nil, fmt.Errorf("listing custom apps: %w", err)
+			}
+			add(app.ID, app.Name, app.Active, true, false, false, false, false)
+		}
+	case "custom_profile":
+		for profile, err := range resultsPages[client.CustomProfile](ctx, c, "/api/v1/library/custom-profiles", nil) {
+			if err != nil {
+				return nil, fmt.Errorf("listing custom profiles: %w", err)
+			}
+			add(profile.ID, profile.Name, profile.Active, profile.RunsOnMac, profile.RunsOnIPhone, profile.RunsOnIPad, profile.RunsOnTV, profile.RunsOnVision)
+		}
+	case "custom_script":
+		for script, err := range resultsPages[client.CustomScript](ctx, c, "/api/v1/library/custom-scripts", nil) {
+			if err != nil {
+				return nil, fmt.Errorf("listing custom scripts: %w", err)
+			}
+			add(script.ID, script.Name, script.Active, true, false, false, false, false)
+		}
+	case "in_house_app":
+		for app, err := range resultsPages[client.InHouseApp](ctx, c, "/api/v1/library/ipa-apps", nil) {
+			if err != nil {
+				return nil, fmt.Errorf("listing in-house apps: %w", err)
+			}
+			add(app.ID, app.Name, app.Active, false, app.RunsOnIPhone, app.RunsOnIPad, app.RunsOnTV, false)
+		}
+	}
+	return items, nil
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+)
+
+func TestListLibraryItems(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/library/custom-apps":
+			_, _ = w.Write([]byte(`{"results": [{"id": "app-1", "name": "Zoom", "active": true}]}`))
+		case "/api/v1/library/custom-profiles":
+			_, _ = w.Write([]byte(`{"results": [{"id": "profile-1", "name": "Wi-Fi", "active": false, "runs_on_mac": true, "runs_on_ipad": true}]}`))
+		case "/api/v1/library/ipa-apps":
+			_, _ = w.Write([]byte(`{"results": [{"id": "ipa-1", "name": "Kiosk", "active": true, "runs_on_iphone": true}]}`))
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	items, err := listLibraryItems(context.Background(), client.NewClient(server.URL, "test-token"), []string{"in_house_app", "custom_app", "custom_profile"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(items) != 3 {
+		t.Fatalf("Expected 3 library items, got %d", len(items))
+	}
+
+	expected := []struct {
+		id, itemType string
+		mac, iphone  bool
+		ipad         bool
+	}{
+		{"app-1", "custom_app", true, false, false},
+		{"profile-1", "custom_profile", true, false, true},
+		{"ipa-1", "in_house_app", false, true, false},
+	}
+	for i, want := range expected {
+		item := items[i]
+		if item.ID.ValueString() != want.id || item.Type.ValueString() != want.itemType {
+			t.Errorf("Expected item %d to be %s %s, got %s %s", i, want.itemType, want.id, item.Type.ValueString(), item.ID.ValueString())
+		}
+		if item.RunsOnMac.ValueBool() != want.mac || item.RunsOnIPhone.ValueBool() != want.iphone || item.RunsOnIPad.ValueBool() != want.ipad {
+			t.Errorf("Unexpected platform flags for %s: %+v", want.id, item)
+		}
+	}
+}
@@ -0,0 +1,335 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource                   = &localAdminReportDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &localAdminReportDataSource{}
+)
+
+func NewLocalAdminReportDataSource() datasource.DataSource {
+	return &localAdminReportDataSource{}
+}
+
+type localAdminReportDataSource struct {
+	client *client.Client
+}
+
+type localAdminReportDataSourceModel struct {
+	ID                     types.String            `tfsdk:"id"`
+	AllowedAdmins          types.List              `tfsdk:"allowed_admins"`
+	AllowedAdminPatterns   types.List              `tfsdk:"allowed_admin_patterns"`
+	BlueprintIDs           types.List              `tfsdk:"blueprint_ids"`
+	DeviceFamilies         types.List              `tfsdk:"device_families"`
+	TotalDevices           types.Int64             `tfsdk:"total_devices"`
+	TotalAdmins            types.Int64             `tfsdk:"total_admins"`
+	UnexpectedAdminCount   types.Int64             `tfsdk:"unexpected_admin_count"`
+	HiddenAdminCount       types.Int64             `tfsdk:"hidden_admin_count"`
+	NonFileVaultAdminCount types.Int64             `tfsdk:"non_filevault_admin_count"`
+	Devices                []localAdminDeviceModel `tfsdk:"devices"`
+}
+
+type localAdminDeviceModel struct {
+	DeviceID         types.String          `tfsdk:"device_id"`
+	DeviceName       types.String          `tfsdk:"device_name"`
+	SerialNumber     types.String          `tfsdk:"serial_number"`
+	UnexpectedAdmins []localAdminUserModel `tfsdk:"unexpected_admins"`
+	HiddenAdmins     []string              `tfsdk:"hidden_admins"`
+	NonFileVault     []string              `tfsdk:"non_filevault_admins"`
+}
+
+type localAdminUserModel struct {
+	Username      types.String `tfsdk:"username"`
+	FullName      types.String `tfsdk:"full_name"`
+	UID           types.Int64  `tfsdk:"uid"`
+	HiddenUser    types.Bool   `tfsdk:"hidden_user"`
+	FileVaultUser types.Bool   `tfsdk:"filevault_user"`
+	LoggedIn      types.Bool   `tfsdk:"logged_in"`
+}
+
+func (d *localAdminReportDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_local_admin_report"
+}
+
+func (d *localAdminReportDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Audit the local administrator accounts reported by Prism. Returns, per device, the admin users outside an allowed set, along with the admins that are hidden or cannot unlock FileVault. Every admin counts towards the hidden and FileVault findings, whether or not it is allowed.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"allowed_admins": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Usernames allowed to be administrators, matched exactly.",
+			},
+			"allowed_admin_patterns": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Glob patterns of usernames allowed to be administrators, such as `it-*`. `*` and `?` are wildcards and the whole username must match.",
+			},
+			"blueprint_ids": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Only audit devices in these blueprints.",
+			},
+			"device_families": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Only audit these device families, such as `Mac`.",
+			},
+			"total_devices": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of devices with at least one local user.",
+			},
+			"total_admins": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of admin accounts across all devices.",
+			},
+			"unexpected_admin_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of admin accounts outside the allowed set across all devices.",
+			},
+			"hidden_admin_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of hidden admin accounts across all devices.",
+			},
+			"non_filevault_admin_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of admin accounts that are not FileVault-enabled across all devices.",
+			},
+			"devices": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The devices with at least one finding, ordered by device name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"device_id":     schema.StringAttribute{Computed: true},
+						"device_name":   schema.StringAttribute{Computed: true},
+						"serial_number": schema.StringAttribute{Computed: true},
+						"unexpected_admins": schema.ListNestedAttribute{
+							Computed:            true,
+							MarkdownDescription: "The admin users outside the allowed set.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"username":       schema.StringAttribute{Computed: true},
+									"full_name":      schema.StringAttribute{Computed: true},
+									"uid":            schema.Int64Attribute{Computed: true},
+									"hidden_user":    schema.BoolAttribute{Computed: true},
+									"filevault_user": schema.BoolAttribute{Computed: true},
+									"logged_in":      schema.BoolAttribute{Computed: true},
+								},
+							},
+						},
+						"hidden_admins": schema.ListAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "The usernames of hidden admin users.",
+						},
+						"non_filevault_admins": schema.ListAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "The usernames of admin users that are not FileVault-enabled.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *localAdminReportDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *localAdminReportDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data localAdminReportDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || !isFullyKnown(ctx, data.AllowedAdminPatterns) {
+		return
+	}
+
+	var patterns []string
+	resp.Diagnostics.Append(data.AllowedAdminPatterns.ElementsAs(ctx, &patterns, false)...)
+	_, diags := newLocalAdminAllowlist(nil, patterns)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (d *localAdminReportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data localAdminReportDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var admins, patterns []string
+	resp.Diagnostics.Append(data.AllowedAdmins.ElementsAs(ctx, &admins, false)...)
+	resp.Diagnostics.Append(data.AllowedAdminPatterns.ElementsAs(ctx, &patterns, false)...)
+	allowlist, diags := newLocalAdminAllowlist(admins, patterns)
+	resp.Diagnostics.Append(diags...)
+	query, diags := newPrismQuery(ctx, "local_users", data.BlueprintIDs, data.DeviceFamilies, types.ObjectNull(nil), types.StringNull())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	users, err := readPrism[client.PrismEntry](ctx, d.client, query, types.Int64Null(), types.Int64Null())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prism local_users, got error: %s", err))
+		return
+	}
+
+	report := auditLocalAdmins(users, allowlist)
+
+	data.ID = types.StringValue("local_admin_report")
+	data.Devices = report.devices
+	data.TotalDevices = types.Int64Value(int64(report.totalDevices))
+	data.TotalAdmins = types.Int64Value(int64(report.totalAdmins))
+	data.UnexpectedAdminCount = types.Int64Value(int64(report.unexpected))
+	data.HiddenAdminCount = types.Int64Value(int64(report.hidden))
+	data.NonFileVaultAdminCount = types.Int64Value(int64(report.nonFileVault))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+type localAdminAllowlist struct {
+	usernames map[string]bool
+	patterns  []func(string) bool
+}
+
+func newLocalAdminAllowlist(usernames, patterns []string) (localAdminAllowlist, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	allowlist := localAdminAllowlist{usernames: map[string]bool{}}
+	for _, username := range usernames {
+		allowlist.usernames[username] = true
+	}
+	for i, pattern := range patterns {
+		match, err := compileADEPattern("glob", pattern)
+		if err != nil {
+			diags.AddAttributeError(path.Root("allowed_admin_patterns").AtListIndex(i), "Invalid Pattern", fmt.Sprintf("Unable to compile glob pattern %q, got error: %s", pattern, err))
+			continue
+		}
+		allowlist.patterns = append(allowlist.patterns, match)
+	}
+	return allowlist, diags
+}
+
+func (a localAdminAllowlist) allows(username string) bool {
+	if a.usernames[username] {
+		return true
+	}
+	for _, match := range a.patterns {
+		if match(username) {
+			return true
+		}
+	}
+	return false
+}
+
+// isLocalAdmin reports whether a local_users row is an administrator. Prism
+// reports the account type as, for example, `Admin` or `Standard`.
+func isLocalAdmin(row client.PrismEntry) bool {
+	userType, _ := row["type"].(string)
+	return strings.Contains(strings.ToLower(userType), "admin")
+}
+
+type localAdminReport struct {
+	devices      []localAdminDeviceModel
+	totalDevices int
+	totalAdmins  int
+	unexpected   int
+	hidden       int
+	nonFileVault int
+}
+
+// auditLocalAdmins groups the local users by device and returns the devices
+// with unexpected, hidden or non-FileVault admins.
+func auditLocalAdmins(users []client.PrismEntry, allowlist localAdminAllowlist) localAdminReport {
+	var report localAdminReport
+	var order []string
+	devices := map[string]*localAdminDeviceModel{}
+	for i, row := range users {
+		key := prismDeviceKey(row, i)
+		device, ok := devices[key]
+		if !ok {
+			device = &localAdminDeviceModel{
+				DeviceID:         prismRowString(row, "device_id"),
+				DeviceName:       prismRowString(row, "device__name"),
+				SerialNumber:     prismRowString(row, "serial_number"),
+				UnexpectedAdmins: []localAdminUserModel{},
+				HiddenAdmins:     []string{},
+				NonFileVault:     []string{},
+			}
+			devices[key] = device
+			order = append(order, key)
+		}
+		if !isLocalAdmin(row) {
+			continue
+		}
+
+		report.totalAdmins++
+		username, _ := row["username"].(string)
+		hidden := prismRowBool(row, "hidden_user").ValueBool()
+		// Rows that do not report filevault_user are not counted as admins
+		// without FileVault.
+		fvUser := prismRowBool(row, "filevault_user")
+
+		if !allowlist.allows(username) {
+			report.unexpected++
+			device.UnexpectedAdmins = append(device.UnexpectedAdmins, localAdminUserModel{
+				Username:      types.StringValue(username),
+				FullName:      prismRowString(row, "full_name"),
+				UID:           prismRowInt64(row, "uid"),
+				HiddenUser:    prismRowBool(row, "hidden_user"),
+				FileVaultUser: prismRowBool(row, "filevault_user"),
+				LoggedIn:      prismRowBool(row, "logged_in"),
+			})
+		}
+		if hidden {
+			report.hidden++
+			device.HiddenAdmins = append(device.HiddenAdmins, username)
+		}
+		if !fvUser.IsNull() && !fvUser.ValueBool() {
+			report.nonFileVault++
+			device.NonFileVault = append(device.NonFileVault, username)
+		}
+	}
+
+	report.totalDevices = len(order)
+	report.devices = []localAdminDeviceModel{}
+	for _, key := range order {
+		device := devices[key]
+		if len(device.UnexpectedAdmins) > 0 || len(device.HiddenAdmins) > 0 || len(device.NonFileVault) > 0 {
+			report.devices = append(report.devices, *device)
+		}
+	}
+	sort.SliceStable(report.devices, func(i, j int) bool {
+		return report.devices[i].DeviceName.ValueString() < report.devices[j].DeviceName.ValueString()
+	})
+	return report
+}
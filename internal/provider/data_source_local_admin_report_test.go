@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+)
+
+func TestAuditLocalAdmins(t *testing.T) {
+	allowlist, diags := newLocalAdminAllowlist([]string{"admin"}, []string{"it-*"})
+	if diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
+	}
+
+	users := []client.PrismEntry{
+		{"device_id": "1", "device__name": "Mac-1", "username": "admin", "type": "Admin", "filevault_user": true},
+		{"device_id": "1", "device__name": "Mac-1", "username": "it-support", "type": "Admin", "hidden_user": true, "filevault_user": true},
+		{"device_id": "1", "device__name": "Mac-1", "username": "alice", "type": "Standard", "filevault_user": true},
+		{"device_id": "2", "device__name": "Mac-2", "username": "admin", "type": "Admin", "filevault_user": true},
+		{"device_id": "2", "device__name": "Mac-2", "username": "bob", "type": "Admin", "uid": float64(502), "logged_in": true, "filevault_user": false},
+		{"device_id": "3", "device__name": "Mac-3", "username": "admin", "type": "Admin"},
+	}
+
+	report := auditLocalAdmins(users, allowlist)
+	if report.totalDevices != 3 || report.totalAdmins != 5 || report.unexpected != 1 || report.hidden != 1 || report.nonFileVault != 1 {
+		t.Errorf("Unexpected totals: %+v", report)
+	}
+	if len(report.devices) != 2 {
+		t.Fatalf("Expected 2 devices with findings, got %v", report.devices)
+	}
+	if got := report.devices[0]; got.DeviceName.ValueString() != "Mac-1" || len(got.UnexpectedAdmins) != 0 || len(got.HiddenAdmins) != 1 || got.HiddenAdmins[0] != "it-support" {
+		t.Errorf("Expected Mac-1 to have an allowed hidden admin, got %v", got)
+	}
+	got := report.devices[1]
+	if got.DeviceName.ValueString() != "Mac-2" || len(got.UnexpectedAdmins) != 1 || len(got.NonFileVault) != 1 {
+		t.Fatalf("Expected Mac-2 to have an unexpected admin without FileVault, got %v", got)
+	}
+	if bob := got.UnexpectedAdmins[0]; bob.Username.ValueString() != "bob" || bob.UID.ValueInt64() != 502 || !bob.LoggedIn.ValueBool() || bob.FileVaultUser.ValueBool() {
+		t.Errorf("Unexpected admin details: %v", bob)
+	}
+}
+
+func TestNewLocalAdminAllowlistPatterns(t *testing.T) {
+	allowlist, diags := newLocalAdminAllowlist(nil, []string{"it-[*]", "*.admin"})
+	if diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
+	}
+	for username, expected := range map[string]bool{
+		"it-[eu]":    true,
+		"it-e":       false,
+		"jane.admin": true,
+		"janeadmin":  false,
+	} {
+		if got := allowlist.allows(username); got != expected {
+			t.Errorf("Expected %q allowed to be %t, got %t", username, expected, got)
+		}
+	}
+}
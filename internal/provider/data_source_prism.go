@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource                   = &prismDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &prismDataSource{}
+)
+
+func NewPrismDataSource() datasource.DataSource {
+	return &prismDataSource{}
+}
+
+type prismDataSource struct {
+	client *client.Client
+}
+
+type prismDataSourceModel struct {
+	ID             types.String  `tfsdk:"id"`
+	Category       types.String  `tfsdk:"category"`
+	BlueprintIDs   types.List    `tfsdk:"blueprint_ids"`
+	DeviceFamilies types.List    `tfsdk:"device_families"`
+	Filter         types.Object  `tfsdk:"filter"`
+	SortBy         types.String  `tfsdk:"sort_by"`
+	Limit          types.Int64   `tfsdk:"limit"`
+	Rows           types.Dynamic `tfsdk:"rows"`
+}
+
+func (d *prismDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_prism"
+}
+
+func (d *prismDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "List the rows of any Prism category. Rows are returned as they come from the API, so categories without a dedicated `iru_prism_*` data source can be read too.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"category": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The Prism category to read, such as `apps`, `filevault` or `local_users`.",
+			},
+			"blueprint_ids": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Only return rows for devices in these blueprints.",
+			},
+			"device_families": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Only return rows for these device families, such as `Mac` or `iPhone`.",
+			},
+			"filter": prismFilterDataSourceAttribute(),
+			"sort_by": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: prismSortByDescription,
+			},
+			"limit": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum number of rows to return. All rows are returned by default.",
+			},
+			"rows": schema.DynamicAttribute{
+				Computed:            true,
+				MarkdownDescription: "The rows of the category. Each row is an object with the fields of the category; fields missing from a row are null.",
+			},
+		},
+	}
+}
+
+func (d *prismDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *prismDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data prismDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validatePrismSortBy(data.Category, data.SortBy)...)
+	_, diags := buildPrismFilter(ctx, data.Category, data.Filter)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (d *prismDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data prismDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	category := data.Category.ValueString()
+	query, diags := newPrismQuery(ctx, category, data.BlueprintIDs, data.DeviceFamilies, data.Filter, data.SortBy)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rows, err := readPrism[client.PrismEntry](ctx, d.client, query, data.Limit, types.Int64Null())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prism %s, got error: %s", category, err))
+		return
+	}
+
+	data.ID = types.StringValue("prism_" + category)
+	data.Rows, diags = prismRowsValue(ctx, rows)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -3,7 +3,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/url"
 
 	"github.com/MScottBlake/terraform-provider-iru/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -11,7 +10,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-var _ datasource.DataSource = &prismActivationLockDataSource{}
+var (
+	_ datasource.DataSource                   = &prismActivationLockDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &prismActivationLockDataSource{}
+)
 
 func NewPrismActivationLockDataSource() datasource.DataSource {
 	return &prismActivationLockDataSource{}
@@ -25,6 +27,8 @@ type prismActivationLockDataSourceModel struct {
 	ID      types.String               `tfsdk:"id"`
 	Limit   types.Int64                `tfsdk:"limit"`
 	Offset  types.Int64                `tfsdk:"offset"`
+	Filter  types.Object               `tfsdk:"filter"`
+	SortBy  types.String               `tfsdk:"sort_by"`
 	Results []prismActivationLockModel `tfsdk:"results"`
 }
 
@@ -54,6 +58,11 @@ func (d *prismActivationLockDataSource) Schema(ctx context.Context, req datasour
 				Optional:            true,
 				MarkdownDescription: "Number of results to skip.",
 			},
+			"filter": prismFilterDataSourceAttribute(),
+			"sort_by": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: prismSortByDescription,
+			},
 			"results": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -76,6 +85,10 @@ func (d *prismActivationLockDataSource) Configure(ctx context.Context, req datas
 	d.client = req.ProviderData.(*client.Client)
 }
 
+func (d *prismActivationLockDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validatePrismDataSourceConfig(ctx, "activation_lock", req.Config)...)
+}
+
 func (d *prismActivationLockDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data prismActivationLockDataSourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -83,56 +96,26 @@ func (d *prismActivationLockDataSource) Read(ctx context.Context, req datasource
 		return
 	}
 
-	var all []client.PrismEntry
-	offset := 0
-	if !data.Offset.IsNull() {
-		offset = int(data.Offset.ValueInt64())
-	}
-	limit := 300
-	if !data.Limit.IsNull() {
-		limit = int(data.Limit.ValueInt64())
+	query, diags := newPrismQuery(ctx, "activation_lock", types.ListNull(types.StringType), types.ListNull(types.StringType), data.Filter, data.SortBy)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	for {
-		params := url.Values{}
-		params.Add("limit", fmt.Sprintf("%d", limit))
-		params.Add("offset", fmt.Sprintf("%d", offset))
-
-		path := "/api/v1/prism/activation_lock?" + params.Encode()
-		type prismResponse struct {
-			Data []client.PrismEntry `json:"data"`
-		}
-		var listResp prismResponse
-
-		err := d.client.DoRequest(ctx, "GET", path, nil, &listResp)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prism activation_lock, got error: %s", err))
-			return
-		}
-
-		all = append(all, listResp.Data...)
-
-		if !data.Limit.IsNull() && len(all) >= limit {
-			all = all[:limit]
-			break
-		}
-
-		if len(listResp.Data) < limit {
-			break
-		}
-		offset += len(listResp.Data)
+	all, err := readPrism[client.PrismEntry](ctx, d.client, query, data.Limit, data.Offset)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prism activation_lock, got error: %s", err))
+		return
 	}
 
 	data.ID = types.StringValue("prism_activation_lock")
 	data.Results = make([]prismActivationLockModel, 0, len(all))
 	for _, item := range all {
-		enabled, _ := item["activation_lock_enabled"].(bool)
-
 		data.Results = append(data.Results, prismActivationLockModel{
-			DeviceID:              types.StringValue(fmt.Sprintf("%v", item["device_id"])),
-			DeviceName:            types.StringValue(fmt.Sprintf("%v", item["device__name"])),
-			SerialNumber:          types.StringValue(fmt.Sprintf("%v", item["serial_number"])),
-			ActivationLockEnabled: types.BoolValue(enabled),
+			DeviceID:              prismRowString(item, "device_id"),
+			DeviceName:            prismRowString(item, "device__name"),
+			SerialNumber:          prismRowString(item, "serial_number"),
+			ActivationLockEnabled: prismRowBool(item, "activation_lock_enabled"),
 		})
 	}
 
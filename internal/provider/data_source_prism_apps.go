@@ -3,7 +3,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/url"
 
 	"github.com/MScottBlake/terraform-provider-iru/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -11,7 +10,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-var _ datasource.DataSource = &prismAppsDataSource{}
+var (
+	_ datasource.DataSource                   = &prismAppsDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &prismAppsDataSource{}
+)
 
 func NewPrismAppsDataSource() datasource.DataSource {
 	return &prismAppsDataSource{}
@@ -25,6 +27,8 @@ type prismAppsDataSourceModel struct {
 	ID      types.String    `tfsdk:"id"`
 	Limit   types.Int64     `tfsdk:"limit"`
 	Offset  types.Int64     `tfsdk:"offset"`
+	Filter  types.Object    `tfsdk:"filter"`
+	SortBy  types.String    `tfsdk:"sort_by"`
 	Results []prismAppModel `tfsdk:"results"`
 }
 
@@ -57,6 +61,11 @@ func (d *prismAppsDataSource) Schema(ctx context.Context, req datasource.SchemaR
 				Optional:            true,
 				MarkdownDescription: "Number of results to skip.",
 			},
+			"filter": prismFilterDataSourceAttribute(),
+			"sort_by": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: prismSortByDescription,
+			},
 			"results": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -82,6 +91,10 @@ func (d *prismAppsDataSource) Configure(ctx context.Context, req datasource.Conf
 	d.client = req.ProviderData.(*client.Client)
 }
 
+func (d *prismAppsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validatePrismDataSourceConfig(ctx, "apps", req.Config)...)
+}
+
 func (d *prismAppsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data prismAppsDataSourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -89,44 +102,16 @@ func (d *prismAppsDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
-	var all []client.PrismApp
-	offset := 0
-	if !data.Offset.IsNull() {
-		offset = int(data.Offset.ValueInt64())
-	}
-	limit := 300
-	if !data.Limit.IsNull() {
-		limit = int(data.Limit.ValueInt64())
+	query, diags := newPrismQuery(ctx, "apps", types.ListNull(types.StringType), types.ListNull(types.StringType), data.Filter, data.SortBy)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	for {
-		params := url.Values{}
-		params.Add("limit", fmt.Sprintf("%d", limit))
-		params.Add("offset", fmt.Sprintf("%d", offset))
-
-		path := "/api/v1/prism/apps?" + params.Encode()
-		type prismAppResponse struct {
-			Data []client.PrismApp `json:"data"`
-		}
-		var listResp prismAppResponse
-
-		err := d.client.DoRequest(ctx, "GET", path, nil, &listResp)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prism apps, got error: %s", err))
-			return
-		}
-
-		all = append(all, listResp.Data...)
-
-		if !data.Limit.IsNull() && len(all) >= limit {
-			all = all[:limit]
-			break
-		}
-
-		if len(listResp.Data) < limit {
-			break
-		}
-		offset += len(listResp.Data)
+	all, err := readPrism[client.PrismApp](ctx, d.client, query, data.Limit, data.Offset)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prism apps, got error: %s", err))
+		return
 	}
 
 	data.ID = types.StringValue("prism_apps")
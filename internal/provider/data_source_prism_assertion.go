@@ -0,0 +1,269 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource                   = &prismAssertionDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &prismAssertionDataSource{}
+)
+
+func NewPrismAssertionDataSource() datasource.DataSource {
+	return &prismAssertionDataSource{}
+}
+
+type prismAssertionDataSource struct {
+	client *client.Client
+}
+
+type prismAssertionDataSourceModel struct {
+	ID             types.String                  `tfsdk:"id"`
+	Category       types.String                  `tfsdk:"category"`
+	BlueprintIDs   types.List                    `tfsdk:"blueprint_ids"`
+	DeviceFamilies types.List                    `tfsdk:"device_families"`
+	Filter         types.Object                  `tfsdk:"filter"`
+	Predicate      types.Object                  `tfsdk:"predicate"`
+	MinPercentage  types.Float64                 `tfsdk:"min_percentage"`
+	MaxViolations  types.Int64                   `tfsdk:"max_violations"`
+	ErrorOnFailure types.Bool                    `tfsdk:"error_on_failure"`
+	Passed         types.Bool                    `tfsdk:"passed"`
+	Percentage     types.Float64                 `tfsdk:"percentage"`
+	TotalDevices   types.Int64                   `tfsdk:"total_devices"`
+	PassingDevices types.Int64                   `tfsdk:"passing_devices"`
+	Violators      []prismAssertionViolatorModel `tfsdk:"violators"`
+}
+
+type prismAssertionViolatorModel struct {
+	DeviceID     types.String `tfsdk:"device_id"`
+	DeviceName   types.String `tfsdk:"device_name"`
+	SerialNumber types.String `tfsdk:"serial_number"`
+}
+
+func (d *prismAssertionDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_prism_assertion"
+}
+
+func (d *prismAssertionDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	predicate := prismFilterDataSourceAttribute()
+	predicate.Optional = false
+	predicate.Required = true
+	predicate.MarkdownDescription = "The condition every row of a compliant device satisfies, in the same form as `filter`. A device violates the assertion when any of its rows does not satisfy the predicate. `like` matches substrings ignoring case."
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Assert that enough devices satisfy a condition in a Prism category, such as FileVault being enabled with an escrowed key. Use `passed` in a `check` block or an output precondition, or set `error_on_failure` to fail the plan.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"category": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The Prism category to assert over, such as `filevault`, `application_firewall`, `gatekeeper_and_xprotect` or `startup_settings`.",
+			},
+			"blueprint_ids": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Only assert over devices in these blueprints.",
+			},
+			"device_families": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Only assert over these device families, such as `Mac`.",
+			},
+			"filter":    prismFilterDataSourceAttribute(),
+			"predicate": predicate,
+			"min_percentage": schema.Float64Attribute{
+				Optional:            true,
+				MarkdownDescription: "The assertion passes when at least this percentage of devices satisfies the predicate, such as `98`.",
+			},
+			"max_violations": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "The assertion passes when at most this many devices violate the predicate. Defaults to `0` when `min_percentage` is not set.",
+			},
+			"error_on_failure": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Return an error diagnostic when the assertion fails. Defaults to `false`.",
+			},
+			"passed": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the assertion passed.",
+			},
+			"percentage": schema.Float64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The percentage of devices that satisfy the predicate. `100` when there are no devices.",
+			},
+			"total_devices": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of devices asserted over.",
+			},
+			"passing_devices": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of devices that satisfy the predicate.",
+			},
+			"violators": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The devices that violate the predicate.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"device_id":     schema.StringAttribute{Computed: true},
+						"device_name":   schema.StringAttribute{Computed: true},
+						"serial_number": schema.StringAttribute{Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *prismAssertionDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *prismAssertionDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data prismAssertionDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, diags := buildPrismFilter(ctx, data.Category, data.Filter)
+	resp.Diagnostics.Append(diags...)
+	_, diags = prismFilterExpr(ctx, path.Root("predicate"), data.Category, data.Predicate)
+	resp.Diagnostics.Append(diags...)
+
+	if !data.MinPercentage.IsNull() && !data.MinPercentage.IsUnknown() {
+		if p := data.MinPercentage.ValueFloat64(); p < 0 || p > 100 {
+			resp.Diagnostics.AddAttributeError(path.Root("min_percentage"), "Invalid Min Percentage", fmt.Sprintf("`min_percentage` must be between 0 and 100, got %v.", p))
+		}
+	}
+	if !data.MaxViolations.IsNull() && !data.MaxViolations.IsUnknown() && data.MaxViolations.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("max_violations"), "Invalid Max Violations", "`max_violations` must not be negative.")
+	}
+}
+
+func (d *prismAssertionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data prismAssertionDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	category := data.Category.ValueString()
+	query, diags := newPrismQuery(ctx, category, data.BlueprintIDs, data.DeviceFamilies, data.Filter, types.StringNull())
+	resp.Diagnostics.Append(diags...)
+	predicate, diags := prismFilterExpr(ctx, path.Root("predicate"), data.Category, data.Predicate)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rows, err := readPrism[client.PrismEntry](ctx, d.client, query, types.Int64Null(), types.Int64Null())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prism %s, got error: %s", category, err))
+		return
+	}
+
+	result := assertPrismRows(rows, predicate)
+
+	maxViolations := data.MaxViolations
+	if maxViolations.IsNull() && data.MinPercentage.IsNull() {
+		maxViolations = types.Int64Value(0)
+	}
+	passed := true
+	if !data.MinPercentage.IsNull() && result.percentage < data.MinPercentage.ValueFloat64() {
+		passed = false
+	}
+	if !maxViolations.IsNull() && int64(len(result.violators)) > maxViolations.ValueInt64() {
+		passed = false
+	}
+
+	data.ID = types.StringValue("prism_assertion_" + category)
+	data.Passed = types.BoolValue(passed)
+	data.Percentage = types.Float64Value(result.percentage)
+	data.TotalDevices = types.Int64Value(int64(result.total))
+	data.PassingDevices = types.Int64Value(int64(result.total - len(result.violators)))
+	data.Violators = make([]prismAssertionViolatorModel, 0, len(result.violators))
+	for _, row := range result.violators {
+		data.Violators = append(data.Violators, prismAssertionViolatorModel{
+			DeviceID:     prismRowString(row, "device_id"),
+			DeviceName:   prismRowString(row, "device__name"),
+			SerialNumber: prismRowString(row, "serial_number"),
+		})
+	}
+
+	if !passed && data.ErrorOnFailure.ValueBool() {
+		resp.Diagnostics.AddError("Prism Assertion Failed", fmt.Sprintf("%d of %d devices (%.2f%%) satisfy the predicate on %s; %d devices violate it.", result.total-len(result.violators), result.total, result.percentage, category, len(result.violators)))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+type prismAssertionResult struct {
+	total      int
+	percentage float64
+	// violators holds the first violating row of each violating device.
+	violators []client.PrismEntry
+}
+
+// assertPrismRows evaluates predicate per device. Rows are grouped by device
+// ID, falling back to the serial number, and a device violates the predicate
+// when any of its rows does.
+func assertPrismRows(rows []client.PrismEntry, predicate any) prismAssertionResult {
+	var order []string
+	violations := map[string]client.PrismEntry{}
+	seen := map[string]bool{}
+	for i, row := range rows {
+		key := prismDeviceKey(row, i)
+		if !seen[key] {
+			seen[key] = true
+			order = append(order, key)
+		}
+		if _, ok := violations[key]; !ok && !matchPrismExpr(predicate, row) {
+			violations[key] = row
+		}
+	}
+
+	result := prismAssertionResult{total: len(order), percentage: 100}
+	for _, key := range order {
+		if row, ok := violations[key]; ok {
+			result.violators = append(result.violators, row)
+		}
+	}
+	sort.SliceStable(result.violators, func(i, j int) bool {
+		return fmt.Sprint(result.violators[i]["device__name"]) < fmt.Sprint(result.violators[j]["device__name"])
+	})
+	if result.total > 0 {
+		result.percentage = float64(result.total-len(result.violators)) * 100 / float64(result.total)
+	}
+	return result
+}
+
+func prismDeviceKey(row client.PrismEntry, index int) string {
+	for _, field := range []string{"device_id", "serial_number"} {
+		if v, ok := row[field].(string); ok && v != "" {
+			return field + ":" + v
+		}
+	}
+	return fmt.Sprintf("row:%d", index)
+}
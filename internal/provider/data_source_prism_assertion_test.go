@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestAssertPrismRows(t *testing.T) {
+	predicate, diags := prismFilterExpr(context.Background(), path.Root("predicate"), types.StringValue("filevault"), prismFilterValue(t, prismFilterModel{
+		Conditions: []prismFilterConditionModel{
+			prismCondition("status", "eq", "true"),
+			prismCondition("key_escrowed", "eq", "true"),
+		},
+	}))
+	if diags.HasError() {
+		t.Fatalf("Expected no error, got %v", diags)
+	}
+
+	rows := []client.PrismEntry{
+		{"device_id": "1", "device__name": "Mac-1", "status": true, "key_escrowed": true},
+		{"device_id": "2", "device__name": "Mac-2", "status": true, "key_escrowed": false},
+		{"device_id": "3", "device__name": "Mac-3", "status": true},
+		{"device_id": "4", "device__name": "Mac-4", "status": true, "key_escrowed": true},
+	}
+
+	result := assertPrismRows(rows, predicate)
+	if result.total != 4 || result.percentage != 50 {
+		t.Errorf("Expected 4 devices at 50%%, got %d at %v", result.total, result.percentage)
+	}
+	if len(result.violators) != 2 || result.violators[0]["device_id"] != "2" || result.violators[1]["device_id"] != "3" {
+		t.Errorf("Expected devices 2 and 3 to violate, got %v", result.violators)
+	}
+
+	if empty := assertPrismRows(nil, predicate); empty.total != 0 || empty.percentage != 100 {
+		t.Errorf("Expected no devices at 100%%, got %v", empty)
+	}
+}
+
+func TestAssertPrismRowsGroupsDevices(t *testing.T) {
+	predicate, _ := prismFilterExpr(context.Background(), path.Root("predicate"), types.StringValue("local_users"), prismFilterValue(t, prismFilterModel{
+		Match: types.StringValue("any"),
+		Conditions: []prismFilterConditionModel{
+			prismCondition("hidden_user", "eq", "false"),
+			prismCondition("username", "in", "", "_mbsetupuser", "root"),
+		},
+	}))
+
+	rows := []client.PrismEntry{
+		{"device_id": "1", "username": "alice", "hidden_user": false},
+		{"device_id": "1", "username": "root", "hidden_user": true},
+		{"device_id": "2", "username": "bob", "hidden_user": false},
+		{"device_id": "2", "username": "backdoor", "hidden_user": true},
+	}
+
+	result := assertPrismRows(rows, predicate)
+	if result.total != 2 || len(result.violators) != 1 || result.violators[0]["username"] != "backdoor" {
+		t.Errorf("Expected device 2 to violate through its backdoor user, got %v", result.violators)
+	}
+}
+
+func TestMatchPrismCondition(t *testing.T) {
+	tests := []struct {
+		got      any
+		operator string
+		want     any
+		match    bool
+	}{
+		{float64(14), "gte", float64(13), true},
+		{"10", "gt", float64(9), true},
+		{"Corporate Wi-Fi", "like", "wi-fi", true},
+		{"Corporate Wi-Fi", "not_like", "wi-fi", false},
+		{"Full", "in", []any{"Full", "Reduced"}, true},
+		{"Full", "not_in", []any{"Full", "Reduced"}, false},
+		{nil, "neq", "x", false},
+		{true, "eq", true, true},
+	}
+
+	for _, tt := range tests {
+		if got := matchPrismCondition(tt.got, tt.operator, tt.want); got != tt.match {
+			t.Errorf("%v %s %v: expected %v, got %v", tt.got, tt.operator, tt.want, tt.match, got)
+		}
+	}
+}
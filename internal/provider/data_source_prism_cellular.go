@@ -3,7 +3,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/url"
 
 	"github.com/MScottBlake/terraform-provider-iru/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -11,7 +10,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-var _ datasource.DataSource = &prismCellularDataSource{}
+var (
+	_ datasource.DataSource                   = &prismCellularDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &prismCellularDataSource{}
+)
 
 func NewPrismCellularDataSource() datasource.DataSource {
 	return &prismCellularDataSource{}
@@ -25,6 +27,8 @@ type prismCellularDataSourceModel struct {
 	ID      types.String         `tfsdk:"id"`
 	Limit   types.Int64          `tfsdk:"limit"`
 	Offset  types.Int64          `tfsdk:"offset"`
+	Filter  types.Object         `tfsdk:"filter"`
+	SortBy  types.String         `tfsdk:"sort_by"`
 	Results []prismCellularModel `tfsdk:"results"`
 }
 
@@ -57,6 +61,11 @@ func (d *prismCellularDataSource) Schema(ctx context.Context, req datasource.Sch
 				Optional:            true,
 				MarkdownDescription: "Number of results to skip.",
 			},
+			"filter": prismFilterDataSourceAttribute(),
+			"sort_by": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: prismSortByDescription,
+			},
 			"results": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -82,6 +91,10 @@ func (d *prismCellularDataSource) Configure(ctx context.Context, req datasource.
 	d.client = req.ProviderData.(*client.Client)
 }
 
+func (d *prismCellularDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validatePrismDataSourceConfig(ctx, "cellular", req.Config)...)
+}
+
 func (d *prismCellularDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data prismCellularDataSourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -89,62 +102,29 @@ func (d *prismCellularDataSource) Read(ctx context.Context, req datasource.ReadR
 		return
 	}
 
-	var all []client.PrismEntry
-	offset := 0
-	if !data.Offset.IsNull() {
-		offset = int(data.Offset.ValueInt64())
-	}
-	limit := 300
-	if !data.Limit.IsNull() {
-		limit = int(data.Limit.ValueInt64())
+	query, diags := newPrismQuery(ctx, "cellular", types.ListNull(types.StringType), types.ListNull(types.StringType), data.Filter, data.SortBy)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	for {
-		params := url.Values{}
-		params.Add("limit", fmt.Sprintf("%d", limit))
-		params.Add("offset", fmt.Sprintf("%d", offset))
-
-		path := "/api/v1/prism/cellular?" + params.Encode()
-		type prismResponse struct {
-			Data []client.PrismEntry `json:"data"`
-		}
-		var listResp prismResponse
-
-		err := d.client.DoRequest(ctx, "GET", path, nil, &listResp)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prism cellular, got error: %s", err))
-			return
-		}
-
-		all = append(all, listResp.Data...)
-
-		if !data.Limit.IsNull() && len(all) >= limit {
-			all = all[:limit]
-			break
-		}
-
-		if len(listResp.Data) < limit {
-			break
-		}
-		offset += len(listResp.Data)
+	all, err := readPrism[client.PrismEntry](ctx, d.client, query, data.Limit, data.Offset)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prism cellular, got error: %s", err))
+		return
 	}
 
 	data.ID = types.StringValue("prism_cellular")
 	data.Results = make([]prismCellularModel, 0, len(all))
 	for _, item := range all {
-		carrier, _ := item["carrier"].(string)
-		phone, _ := item["phone_number"].(string)
-		imei, _ := item["imei"].(string)
-		iccid, _ := item["iccid"].(string)
-
 		data.Results = append(data.Results, prismCellularModel{
-			DeviceID:     types.StringValue(fmt.Sprintf("%v", item["device_id"])),
-			DeviceName:   types.StringValue(fmt.Sprintf("%v", item["device__name"])),
-			SerialNumber: types.StringValue(fmt.Sprintf("%v", item["serial_number"])),
-			Carrier:      types.StringValue(carrier),
-			PhoneNumber:  types.StringValue(phone),
-			IMEI:         types.StringValue(imei),
-			ICCID:        types.StringValue(iccid),
+			DeviceID:     prismRowString(item, "device_id"),
+			DeviceName:   prismRowString(item, "device__name"),
+			SerialNumber: prismRowString(item, "serial_number"),
+			Carrier:      prismRowString(item, "carrier"),
+			PhoneNumber:  prismRowString(item, "phone_number"),
+			IMEI:         prismRowString(item, "imei"),
+			ICCID:        prismRowString(item, "iccid"),
 		})
 	}
 
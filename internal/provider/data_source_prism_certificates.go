@@ -3,29 +3,49 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/url"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/MScottBlake/terraform-provider-iru/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-var _ datasource.DataSource = &prismCertificatesDataSource{}
+var (
+	_ datasource.DataSource                   = &prismCertificatesDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &prismCertificatesDataSource{}
+)
+
+// certificateWarningLimit caps the certificate groups listed in the expiry
+// warning.
+const certificateWarningLimit = 10
 
 func NewPrismCertificatesDataSource() datasource.DataSource {
-	return &prismCertificatesDataSource{}
+	return &prismCertificatesDataSource{
+		now: time.Now,
+	}
 }
 
 type prismCertificatesDataSource struct {
 	client *client.Client
+	now    func() time.Time
 }
 
 type prismCertificatesDataSourceModel struct {
-	ID      types.String            `tfsdk:"id"`
-	Limit   types.Int64             `tfsdk:"limit"`
-	Offset  types.Int64             `tfsdk:"offset"`
-	Results []prismCertificateModel `tfsdk:"results"`
+	ID             types.String                 `tfsdk:"id"`
+	Limit          types.Int64                  `tfsdk:"limit"`
+	Offset         types.Int64                  `tfsdk:"offset"`
+	Filter         types.Object                 `tfsdk:"filter"`
+	SortBy         types.String                 `tfsdk:"sort_by"`
+	ExpiringWithin types.String                 `tfsdk:"expiring_within"`
+	Expired        types.Bool                   `tfsdk:"expired"`
+	Results        []prismCertificateModel      `tfsdk:"results"`
+	Summary        []prismCertificateGroupModel `tfsdk:"summary"`
 }
 
 type prismCertificateModel struct {
@@ -34,6 +54,21 @@ type prismCertificateModel struct {
 	SerialNumber        types.String `tfsdk:"serial_number"`
 	CommonName          types.String `tfsdk:"common_name"`
 	IdentityCertificate types.Bool   `tfsdk:"identity_certificate"`
+	Subject             types.String `tfsdk:"subject"`
+	Issuer              types.String `tfsdk:"issuer"`
+	NotBefore           types.String `tfsdk:"not_before"`
+	NotAfter            types.String `tfsdk:"not_after"`
+	DaysUntilExpiry     types.Int64  `tfsdk:"days_until_expiry"`
+	Expired             types.Bool   `tfsdk:"expired"`
+}
+
+type prismCertificateGroupModel struct {
+	Subject            types.String `tfsdk:"subject"`
+	Issuer             types.String `tfsdk:"issuer"`
+	DeviceCount        types.Int64  `tfsdk:"device_count"`
+	ExpiredCount       types.Int64  `tfsdk:"expired_count"`
+	EarliestNotAfter   types.String `tfsdk:"earliest_not_after"`
+	MinDaysUntilExpiry types.Int64  `tfsdk:"min_days_until_expiry"`
 }
 
 func (d *prismCertificatesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -42,7 +77,7 @@ func (d *prismCertificatesDataSource) Metadata(ctx context.Context, req datasour
 
 func (d *prismCertificatesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "List certificates from Prism.",
+		MarkdownDescription: "List certificates from Prism, with their validity dates in RFC 3339 format and the days left until they expire. When `expiring_within` is set, matching certificates are also reported as a warning during plan.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -55,6 +90,19 @@ func (d *prismCertificatesDataSource) Schema(ctx context.Context, req datasource
 				Optional:            true,
 				MarkdownDescription: "Number of results to skip.",
 			},
+			"filter": prismFilterDataSourceAttribute(),
+			"sort_by": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: prismSortByDescription,
+			},
+			"expiring_within": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return certificates that expire within this period, or have already expired, such as `30d` or `72h`. Combine with `expired = false` to leave out expired certificates.",
+			},
+			"expired": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return expired (`true`) or unexpired (`false`) certificates.",
+			},
 			"results": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -64,6 +112,53 @@ func (d *prismCertificatesDataSource) Schema(ctx context.Context, req datasource
 						"serial_number":        schema.StringAttribute{Computed: true},
 						"common_name":          schema.StringAttribute{Computed: true},
 						"identity_certificate": schema.BoolAttribute{Computed: true},
+						"subject":              schema.StringAttribute{Computed: true},
+						"issuer":               schema.StringAttribute{Computed: true},
+						"not_before": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The start of the validity period in RFC 3339 format.",
+						},
+						"not_after": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The end of the validity period in RFC 3339 format.",
+						},
+						"days_until_expiry": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Whole days until the certificate expires; negative once it has expired.",
+						},
+						"expired": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the certificate has expired.",
+						},
+					},
+				},
+			},
+			"summary": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The returned certificates grouped by subject and issuer, soonest expiry first.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"subject": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The certificate subject, or the common name when Prism reports no subject.",
+						},
+						"issuer": schema.StringAttribute{Computed: true},
+						"device_count": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The number of devices with the certificate.",
+						},
+						"expired_count": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The number of devices where the certificate has expired.",
+						},
+						"earliest_not_after": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The earliest expiry across devices in RFC 3339 format.",
+						},
+						"min_days_until_expiry": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The fewest days until expiry across devices.",
+						},
 					},
 				},
 			},
@@ -78,6 +173,10 @@ func (d *prismCertificatesDataSource) Configure(ctx context.Context, req datasou
 	d.client = req.ProviderData.(*client.Client)
 }
 
+func (d *prismCertificatesDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validatePrismDataSourceConfig(ctx, "certificates", req.Config)...)
+}
+
 func (d *prismCertificatesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data prismCertificatesDataSourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -85,60 +184,190 @@ func (d *prismCertificatesDataSource) Read(ctx context.Context, req datasource.R
 		return
 	}
 
-	var all []client.PrismEntry
-	offset := 0
-	if !data.Offset.IsNull() {
-		offset = int(data.Offset.ValueInt64())
+	var within time.Duration
+	if !data.ExpiringWithin.IsNull() {
+		parsed, err := parseDays(data.ExpiringWithin.ValueString())
+		if err != nil || parsed < 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("expiring_within"), "Invalid Expiring Within", fmt.Sprintf("`expiring_within` must be a period such as `30d` or `72h`, got %q.", data.ExpiringWithin.ValueString()))
+			return
+		}
+		within = parsed
 	}
-	limit := 300
-	if !data.Limit.IsNull() {
-		limit = int(data.Limit.ValueInt64())
+
+	query, diags := newPrismQuery(ctx, "certificates", types.ListNull(types.StringType), types.ListNull(types.StringType), data.Filter, data.SortBy)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	for {
-		params := url.Values{}
-		params.Add("limit", fmt.Sprintf("%d", limit))
-		params.Add("offset", fmt.Sprintf("%d", offset))
+	// expired and expiring_within are applied by filterCertificates rather
+	// than by Prism, so limit and offset must be applied after them.
+	limit, offset := data.Limit, data.Offset
+	if !data.Expired.IsNull() || !data.ExpiringWithin.IsNull() {
+		limit, offset = types.Int64Null(), types.Int64Null()
+	}
 
-		path := "/api/v1/prism/certificates?" + params.Encode()
-		type prismResponse struct {
-			Data []client.PrismEntry `json:"data"`
-		}
-		var listResp prismResponse
+	all, err := readPrism[client.PrismEntry](ctx, d.client, query, limit, offset)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prism certificates, got error: %s", err))
+		return
+	}
 
-		err := d.client.DoRequest(ctx, "GET", path, nil, &listResp)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prism certificates, got error: %s", err))
-			return
+	data.ID = types.StringValue("prism_certificates")
+	data.Results = filterCertificates(all, data, within, d.now())
+	data.Summary = summarizeCertificates(data.Results)
+
+	if !data.ExpiringWithin.IsNull() && len(data.Summary) > 0 {
+		resp.Diagnostics.AddWarning("Certificates Expiring", certificateWarning(data.Summary, data.ExpiringWithin.ValueString()))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// filterCertificates converts Prism rows into certificates and keeps those
+// matching expired and expiring_within. When either is set, Prism was read
+// without limit and offset, so they are applied to the matches here.
+func filterCertificates(rows []client.PrismEntry, data prismCertificatesDataSourceModel, within time.Duration, now time.Time) []prismCertificateModel {
+	certs := make([]prismCertificateModel, 0, len(rows))
+	for _, item := range rows {
+		cert := newPrismCertificate(item, now)
+		if !data.Expired.IsNull() && cert.Expired.ValueBool() != data.Expired.ValueBool() {
+			continue
 		}
+		if !data.ExpiringWithin.IsNull() {
+			notAfter, ok := parsePrismTime(item["not_after"])
+			if !ok || notAfter.After(now.Add(within)) {
+				continue
+			}
+		}
+		certs = append(certs, cert)
+	}
+	if data.Expired.IsNull() && data.ExpiringWithin.IsNull() {
+		return certs
+	}
+	if skip := data.Offset.ValueInt64(); skip > 0 {
+		certs = certs[min(skip, int64(len(certs))):]
+	}
+	if !data.Limit.IsNull() && data.Limit.ValueInt64() < int64(len(certs)) {
+		certs = certs[:max(data.Limit.ValueInt64(), 0)]
+	}
+	return certs
+}
 
-		all = append(all, listResp.Data...)
+func newPrismCertificate(item client.PrismEntry, now time.Time) prismCertificateModel {
+	cert := prismCertificateModel{
+		DeviceID:            prismRowString(item, "device_id"),
+		DeviceName:          prismRowString(item, "device__name"),
+		SerialNumber:        prismRowString(item, "serial_number"),
+		CommonName:          prismRowString(item, "common_name"),
+		IdentityCertificate: prismRowBool(item, "identity_certificate"),
+		Subject:             prismRowString(item, "subject"),
+		Issuer:              prismRowString(item, "issuer"),
+		NotBefore:           types.StringNull(),
+		NotAfter:            types.StringNull(),
+		DaysUntilExpiry:     types.Int64Null(),
+		Expired:             types.BoolNull(),
+	}
+	if notBefore, ok := parsePrismTime(item["not_before"]); ok {
+		cert.NotBefore = types.StringValue(notBefore.Format(time.RFC3339))
+	}
+	if notAfter, ok := parsePrismTime(item["not_after"]); ok {
+		cert.NotAfter = types.StringValue(notAfter.Format(time.RFC3339))
+		cert.DaysUntilExpiry = types.Int64Value(int64(math.Floor(notAfter.Sub(now).Hours() / 24)))
+		cert.Expired = types.BoolValue(!notAfter.After(now))
+	}
+	return cert
+}
 
-		if !data.Limit.IsNull() && len(all) >= limit {
-			all = all[:limit]
-			break
+// summarizeCertificates groups certificates by subject, falling back to the
+// common name, and issuer. Groups are ordered by soonest expiry; groups
+// without a known expiry come last.
+func summarizeCertificates(certs []prismCertificateModel) []prismCertificateGroupModel {
+	type key struct{ subject, issuer string }
+	groups := map[key]*prismCertificateGroupModel{}
+	devices := map[key]map[string]bool{}
+	expiredDevices := map[key]map[string]bool{}
+	var order []key
+
+	for _, cert := range certs {
+		subject := cert.Subject
+		if subject.IsNull() || subject.ValueString() == "" {
+			subject = cert.CommonName
 		}
+		k := key{subject.ValueString(), cert.Issuer.ValueString()}
 
-		if len(listResp.Data) < limit {
-			break
+		group, ok := groups[k]
+		if !ok {
+			group = &prismCertificateGroupModel{
+				Subject:            subject,
+				Issuer:             cert.Issuer,
+				DeviceCount:        types.Int64Value(0),
+				ExpiredCount:       types.Int64Value(0),
+				EarliestNotAfter:   types.StringNull(),
+				MinDaysUntilExpiry: types.Int64Null(),
+			}
+			groups[k] = group
+			devices[k] = map[string]bool{}
+			expiredDevices[k] = map[string]bool{}
+			order = append(order, k)
+		}
+
+		if !devices[k][cert.DeviceID.ValueString()] {
+			devices[k][cert.DeviceID.ValueString()] = true
+			group.DeviceCount = types.Int64Value(group.DeviceCount.ValueInt64() + 1)
+		}
+		if cert.Expired.ValueBool() && !expiredDevices[k][cert.DeviceID.ValueString()] {
+			expiredDevices[k][cert.DeviceID.ValueString()] = true
+			group.ExpiredCount = types.Int64Value(group.ExpiredCount.ValueInt64() + 1)
+		}
+		if !cert.DaysUntilExpiry.IsNull() && (group.MinDaysUntilExpiry.IsNull() || cert.DaysUntilExpiry.ValueInt64() < group.MinDaysUntilExpiry.ValueInt64()) {
+			group.MinDaysUntilExpiry = cert.DaysUntilExpiry
+		}
+		// RFC 3339 timestamps in UTC sort chronologically as strings.
+		if !cert.NotAfter.IsNull() && (group.EarliestNotAfter.IsNull() || cert.NotAfter.ValueString() < group.EarliestNotAfter.ValueString()) {
+			group.EarliestNotAfter = cert.NotAfter
 		}
-		offset += len(listResp.Data)
 	}
 
-	data.ID = types.StringValue("prism_certificates")
-	data.Results = make([]prismCertificateModel, 0, len(all))
-	for _, item := range all {
-		commonName, _ := item["common_name"].(string)
-		identityCert, _ := item["identity_certificate"].(bool)
+	summary := make([]prismCertificateGroupModel, 0, len(order))
+	for _, k := range order {
+		summary = append(summary, *groups[k])
+	}
+	sort.SliceStable(summary, func(i, j int) bool {
+		a, b := summary[i].EarliestNotAfter, summary[j].EarliestNotAfter
+		if a.IsNull() || b.IsNull() {
+			return !a.IsNull() && b.IsNull()
+		}
+		return a.ValueString() < b.ValueString()
+	})
+	return summary
+}
 
-		data.Results = append(data.Results, prismCertificateModel{
-			DeviceID:            types.StringValue(fmt.Sprintf("%v", item["device_id"])),
-			DeviceName:          types.StringValue(fmt.Sprintf("%v", item["device__name"])),
-			SerialNumber:        types.StringValue(fmt.Sprintf("%v", item["serial_number"])),
-			CommonName:          types.StringValue(commonName),
-			IdentityCertificate: types.BoolValue(identityCert),
-		})
+func certificateWarning(summary []prismCertificateGroupModel, within string) string {
+	var lines []string
+	for i, group := range summary {
+		if i == certificateWarningLimit {
+			lines = append(lines, fmt.Sprintf("- and %d more", len(summary)-certificateWarningLimit))
+			break
+		}
+		expiry := "unknown expiry"
+		if !group.EarliestNotAfter.IsNull() {
+			expiry = "expires " + group.EarliestNotAfter.ValueString()
+		}
+		lines = append(lines, fmt.Sprintf("- %s (issuer %s): %s, on %d devices, %d expired", group.Subject.ValueString(), group.Issuer.ValueString(), expiry, group.DeviceCount.ValueInt64(), group.ExpiredCount.ValueInt64()))
 	}
+	return fmt.Sprintf("Certificates of %d subjects and issuers expire within %s or have expired:\n%s", len(summary), within, strings.Join(lines, "\n"))
+}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+// parseDays parses a Go duration that may also be given in whole days, such
+// as `30d`.
+func parseDays(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(s)
 }
@@ -2,7 +2,10 @@ package provider
 
 import (
 	"testing"
+	"time"
 
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -19,3 +22,92 @@ func TestAccPrismCertificatesDataSource(t *testing.T) {
 		},
 	})
 }
+
+func TestPrismCertificateExpiry(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	rows := []client.PrismEntry{
+		{"device_id": "1", "common_name": "wifi.example.com", "subject": "CN=wifi.example.com", "issuer": "Corp CA", "not_before": "2025-03-10 08:00:00 +0000", "not_after": "2026-03-10 08:00:00 +0000"},
+		{"device_id": "2", "common_name": "wifi.example.com", "subject": "CN=wifi.example.com", "issuer": "Corp CA", "not_after": "2026-02-20T00:00:00Z"},
+		{"device_id": "3", "common_name": "SCEP Identity", "issuer": "Corp CA", "not_after": "Jun  1 00:00:00 2027 GMT"},
+		{"device_id": "4", "common_name": "Unknown", "not_after": "soon"},
+		{"device_id": "2", "common_name": "wifi.example.com", "subject": "CN=wifi.example.com", "issuer": "Corp CA", "not_after": "2026-02-20T00:00:00Z"},
+	}
+
+	certs := make([]prismCertificateModel, 0, len(rows))
+	for _, row := range rows {
+		certs = append(certs, newPrismCertificate(row, now))
+	}
+
+	if got := certs[0].NotBefore.ValueString(); got != "2025-03-10T08:00:00Z" {
+		t.Errorf("Expected not_before in RFC 3339, got %s", got)
+	}
+	if got := certs[0].DaysUntilExpiry.ValueInt64(); got != 8 {
+		t.Errorf("Expected 8 days until expiry, got %d", got)
+	}
+	if !certs[1].Expired.ValueBool() || certs[1].DaysUntilExpiry.ValueInt64() != -10 {
+		t.Errorf("Expected certificate 2 to have expired 10 days ago, got %v, %v", certs[1].Expired, certs[1].DaysUntilExpiry)
+	}
+	if got := certs[2].NotAfter.ValueString(); got != "2027-06-01T00:00:00Z" {
+		t.Errorf("Expected OpenSSL dates to be parsed, got %s", got)
+	}
+	if !certs[3].NotAfter.IsNull() || !certs[3].DaysUntilExpiry.IsNull() || !certs[3].Expired.IsNull() {
+		t.Errorf("Expected unparseable dates to be null, got %v", certs[3])
+	}
+
+	summary := summarizeCertificates(certs)
+	if len(summary) != 3 {
+		t.Fatalf("Expected 3 groups, got %v", summary)
+	}
+	wifi := summary[0]
+	if wifi.Subject.ValueString() != "CN=wifi.example.com" || wifi.DeviceCount.ValueInt64() != 2 || wifi.ExpiredCount.ValueInt64() != 1 {
+		t.Errorf("Unexpected Wi-Fi group %v", wifi)
+	}
+	if wifi.EarliestNotAfter.ValueString() != "2026-02-20T00:00:00Z" || wifi.MinDaysUntilExpiry.ValueInt64() != -10 {
+		t.Errorf("Expected the earliest expiry of the Wi-Fi group, got %v", wifi)
+	}
+	if summary[1].Subject.ValueString() != "SCEP Identity" {
+		t.Errorf("Expected the common name to stand in for a missing subject, got %v", summary[1].Subject)
+	}
+	if !summary[2].EarliestNotAfter.IsNull() {
+		t.Errorf("Expected groups without an expiry last, got %v", summary[2])
+	}
+}
+
+func TestFilterCertificatesLimit(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	rows := []client.PrismEntry{
+		{"device_id": "1", "not_after": "2027-01-01T00:00:00Z"},
+		{"device_id": "2", "not_after": "2026-02-01T00:00:00Z"},
+		{"device_id": "3", "not_after": "2028-01-01T00:00:00Z"},
+		{"device_id": "4", "not_after": "2026-01-01T00:00:00Z"},
+		{"device_id": "5", "not_after": "2025-01-01T00:00:00Z"},
+	}
+
+	data := prismCertificatesDataSourceModel{
+		Limit:          types.Int64Value(2),
+		Offset:         types.Int64Value(1),
+		Expired:        types.BoolValue(true),
+		ExpiringWithin: types.StringNull(),
+	}
+	certs := filterCertificates(rows, data, 0, now)
+	if len(certs) != 2 || certs[0].DeviceID.ValueString() != "4" || certs[1].DeviceID.ValueString() != "5" {
+		t.Errorf("Expected the second and third expired certificates, got %v", certs)
+	}
+
+	data.Expired = types.BoolNull()
+	if certs := filterCertificates(rows, data, 0, now); len(certs) != len(rows) {
+		t.Errorf("Expected limit and offset to be left to Prism without local filters, got %d certificates", len(certs))
+	}
+}
+
+func TestParseDays(t *testing.T) {
+	if d, err := parseDays("30d"); err != nil || d != 30*24*time.Hour {
+		t.Errorf("Expected 30 days, got %v, %v", d, err)
+	}
+	if d, err := parseDays("72h"); err != nil || d != 72*time.Hour {
+		t.Errorf("Expected 72 hours, got %v, %v", d, err)
+	}
+	if _, err := parseDays("soon"); err == nil {
+		t.Error("Expected an error for an invalid period")
+	}
+}
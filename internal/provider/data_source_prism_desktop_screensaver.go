@@ -3,7 +3,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/url"
 
 	"github.com/MScottBlake/terraform-provider-iru/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -11,7 +10,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-var _ datasource.DataSource = &prismDesktopScreensaverDataSource{}
+var (
+	_ datasource.DataSource                   = &prismDesktopScreensaverDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &prismDesktopScreensaverDataSource{}
+)
 
 func NewPrismDesktopScreensaverDataSource() datasource.DataSource {
 	return &prismDesktopScreensaverDataSource{}
@@ -25,6 +27,8 @@ type prismDesktopScreensaverDataSourceModel struct {
 	ID      types.String                   `tfsdk:"id"`
 	Limit   types.Int64                    `tfsdk:"limit"`
 	Offset  types.Int64                    `tfsdk:"offset"`
+	Filter  types.Object                   `tfsdk:"filter"`
+	SortBy  types.String                   `tfsdk:"sort_by"`
 	Results []prismDesktopScreensaverModel `tfsdk:"results"`
 }
 
@@ -56,6 +60,11 @@ func (d *prismDesktopScreensaverDataSource) Schema(ctx context.Context, req data
 				Optional:            true,
 				MarkdownDescription: "Number of results to skip.",
 			},
+			"filter": prismFilterDataSourceAttribute(),
+			"sort_by": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: prismSortByDescription,
+			},
 			"results": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -80,6 +89,10 @@ func (d *prismDesktopScreensaverDataSource) Configure(ctx context.Context, req d
 	d.client = req.ProviderData.(*client.Client)
 }
 
+func (d *prismDesktopScreensaverDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validatePrismDataSourceConfig(ctx, "desktop_and_screensaver", req.Config)...)
+}
+
 func (d *prismDesktopScreensaverDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data prismDesktopScreensaverDataSourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -87,56 +100,28 @@ func (d *prismDesktopScreensaverDataSource) Read(ctx context.Context, req dataso
 		return
 	}
 
-	var all []client.PrismEntry
-	offset := 0
-	if !data.Offset.IsNull() {
-		offset = int(data.Offset.ValueInt64())
-	}
-	limit := 300
-	if !data.Limit.IsNull() {
-		limit = int(data.Limit.ValueInt64())
+	query, diags := newPrismQuery(ctx, "desktop_and_screensaver", types.ListNull(types.StringType), types.ListNull(types.StringType), data.Filter, data.SortBy)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	for {
-		params := url.Values{}
-		params.Add("limit", fmt.Sprintf("%d", limit))
-		params.Add("offset", fmt.Sprintf("%d", offset))
-
-		path := "/api/v1/prism/desktop_and_screensaver?" + params.Encode()
-		type prismResponse struct {
-			Data []client.PrismEntry `json:"data"`
-		}
-		var listResp prismResponse
-
-		err := d.client.DoRequest(ctx, "GET", path, nil, &listResp)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prism desktop_screensaver, got error: %s", err))
-			return
-		}
-
-		all = append(all, listResp.Data...)
-
-		if !data.Limit.IsNull() && len(all) >= limit {
-			all = all[:limit]
-			break
-		}
-
-		if len(listResp.Data) < limit {
-			break
-		}
-		offset += len(listResp.Data)
+	all, err := readPrism[client.PrismEntry](ctx, d.client, query, data.Limit, data.Offset)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prism desktop_screensaver, got error: %s", err))
+		return
 	}
 
 	data.ID = types.StringValue("prism_desktop_screensaver")
 	data.Results = make([]prismDesktopScreensaverModel, 0, len(all))
 	for _, item := range all {
 		data.Results = append(data.Results, prismDesktopScreensaverModel{
-			DeviceID:      types.StringValue(fmt.Sprintf("%v", item["device_id"])),
-			DeviceName:    types.StringValue(fmt.Sprintf("%v", item["device__name"])),
-			SerialNumber:  types.StringValue(fmt.Sprintf("%v", item["serial_number"])),
-			HostName:      types.StringValue(fmt.Sprintf("%v", item["host_name"])),
-			OSVersion:     types.StringValue(fmt.Sprintf("%v", item["os_version"])),
-			MarketingName: types.StringValue(fmt.Sprintf("%v", item["marketing_name"])),
+			DeviceID:      prismRowString(item, "device_id"),
+			DeviceName:    prismRowString(item, "device__name"),
+			SerialNumber:  prismRowString(item, "serial_number"),
+			HostName:      prismRowString(item, "host_name"),
+			OSVersion:     prismRowString(item, "os_version"),
+			MarketingName: prismRowString(item, "marketing_name"),
 		})
 	}
 
@@ -3,7 +3,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/url"
 
 	"github.com/MScottBlake/terraform-provider-iru/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -11,7 +10,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-var _ datasource.DataSource = &prismDeviceInformationDataSource{}
+var (
+	_ datasource.DataSource                   = &prismDeviceInformationDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &prismDeviceInformationDataSource{}
+)
 
 func NewPrismDeviceInformationDataSource() datasource.DataSource {
 	return &prismDeviceInformationDataSource{}
@@ -25,6 +27,8 @@ type prismDeviceInformationDataSourceModel struct {
 	ID      types.String                  `tfsdk:"id"`
 	Limit   types.Int64                   `tfsdk:"limit"`
 	Offset  types.Int64                   `tfsdk:"offset"`
+	Filter  types.Object                  `tfsdk:"filter"`
+	SortBy  types.String                  `tfsdk:"sort_by"`
 	Results []prismDeviceInformationModel `tfsdk:"results"`
 }
 
@@ -58,6 +62,11 @@ func (d *prismDeviceInformationDataSource) Schema(ctx context.Context, req datas
 				Optional:            true,
 				MarkdownDescription: "Number of results to skip.",
 			},
+			"filter": prismFilterDataSourceAttribute(),
+			"sort_by": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: prismSortByDescription,
+			},
 			"results": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -84,6 +93,10 @@ func (d *prismDeviceInformationDataSource) Configure(ctx context.Context, req da
 	d.client = req.ProviderData.(*client.Client)
 }
 
+func (d *prismDeviceInformationDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validatePrismDataSourceConfig(ctx, "device_information", req.Config)...)
+}
+
 func (d *prismDeviceInformationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data prismDeviceInformationDataSourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -91,62 +104,30 @@ func (d *prismDeviceInformationDataSource) Read(ctx context.Context, req datasou
 		return
 	}
 
-	var all []client.PrismEntry
-	offset := 0
-	if !data.Offset.IsNull() {
-		offset = int(data.Offset.ValueInt64())
-	}
-	limit := 300
-	if !data.Limit.IsNull() {
-		limit = int(data.Limit.ValueInt64())
+	query, diags := newPrismQuery(ctx, "device_information", types.ListNull(types.StringType), types.ListNull(types.StringType), data.Filter, data.SortBy)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	for {
-		params := url.Values{}
-		params.Add("limit", fmt.Sprintf("%d", limit))
-		params.Add("offset", fmt.Sprintf("%d", offset))
-
-		path := "/api/v1/prism/device_information?" + params.Encode()
-		type prismResponse struct {
-			Data []client.PrismEntry `json:"data"`
-		}
-		var listResp prismResponse
-
-		err := d.client.DoRequest(ctx, "GET", path, nil, &listResp)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prism device_information, got error: %s", err))
-			return
-		}
-
-		all = append(all, listResp.Data...)
-
-		if !data.Limit.IsNull() && len(all) >= limit {
-			all = all[:limit]
-			break
-		}
-
-		if len(listResp.Data) < limit {
-			break
-		}
-		offset += len(listResp.Data)
+	all, err := readPrism[client.PrismEntry](ctx, d.client, query, data.Limit, data.Offset)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prism device_information, got error: %s", err))
+		return
 	}
 
 	data.ID = types.StringValue("prism_device_information")
 	data.Results = make([]prismDeviceInformationModel, 0, len(all))
 	for _, item := range all {
-		capacity, _ := item["device_capacity"].(float64)
-		mdmEnabled, _ := item["mdm_enabled"].(bool)
-		agentInstalled, _ := item["agent_installed"].(bool)
-
 		data.Results = append(data.Results, prismDeviceInformationModel{
-			DeviceID:       types.StringValue(fmt.Sprintf("%v", item["device_id"])),
-			DeviceName:     types.StringValue(fmt.Sprintf("%v", item["device__name"])),
-			SerialNumber:   types.StringValue(fmt.Sprintf("%v", item["serial_number"])),
-			DeviceCapacity: types.Float64Value(capacity),
-			ModelName:      types.StringValue(fmt.Sprintf("%v", item["model_name"])),
-			OSVersion:      types.StringValue(fmt.Sprintf("%v", item["os_version"])),
-			MDMEnabled:     types.BoolValue(mdmEnabled),
-			AgentInstalled: types.BoolValue(agentInstalled),
+			DeviceID:       prismRowString(item, "device_id"),
+			DeviceName:     prismRowString(item, "device__name"),
+			SerialNumber:   prismRowString(item, "serial_number"),
+			DeviceCapacity: prismRowFloat64(item, "device_capacity"),
+			ModelName:      prismRowString(item, "model_name"),
+			OSVersion:      prismRowString(item, "os_version"),
+			MDMEnabled:     prismRowBool(item, "mdm_enabled"),
+			AgentInstalled: prismRowBool(item, "agent_installed"),
 		})
 	}
 
@@ -3,7 +3,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/url"
 
 	"github.com/MScottBlake/terraform-provider-iru/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -11,7 +10,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-var _ datasource.DataSource = &prismFileVaultDataSource{}
+var (
+	_ datasource.DataSource                   = &prismFileVaultDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &prismFileVaultDataSource{}
+)
 
 func NewPrismFileVaultDataSource() datasource.DataSource {
 	return &prismFileVaultDataSource{}
@@ -25,6 +27,8 @@ type prismFileVaultDataSourceModel struct {
 	ID      types.String          `tfsdk:"id"`
 	Limit   types.Int64           `tfsdk:"limit"`
 	Offset  types.Int64           `tfsdk:"offset"`
+	Filter  types.Object          `tfsdk:"filter"`
+	SortBy  types.String          `tfsdk:"sort_by"`
 	Results []prismFileVaultModel `tfsdk:"results"`
 }
 
@@ -56,6 +60,11 @@ func (d *prismFileVaultDataSource) Schema(ctx context.Context, req datasource.Sc
 				Optional:            true,
 				MarkdownDescription: "Number of results to skip.",
 			},
+			"filter": prismFilterDataSourceAttribute(),
+			"sort_by": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: prismSortByDescription,
+			},
 			"results": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -92,6 +101,10 @@ func (d *prismFileVaultDataSource) Configure(ctx context.Context, req datasource
 	d.client = req.ProviderData.(*client.Client)
 }
 
+func (d *prismFileVaultDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validatePrismDataSourceConfig(ctx, "filevault", req.Config)...)
+}
+
 func (d *prismFileVaultDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data prismFileVaultDataSourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -99,44 +112,16 @@ func (d *prismFileVaultDataSource) Read(ctx context.Context, req datasource.Read
 		return
 	}
 
-	var all []client.PrismFileVault
-	offset := 0
-	if !data.Offset.IsNull() {
-		offset = int(data.Offset.ValueInt64())
-	}
-	limit := 300
-	if !data.Limit.IsNull() {
-		limit = int(data.Limit.ValueInt64())
+	query, diags := newPrismQuery(ctx, "filevault", types.ListNull(types.StringType), types.ListNull(types.StringType), data.Filter, data.SortBy)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	for {
-		params := url.Values{}
-		params.Add("limit", fmt.Sprintf("%d", limit))
-		params.Add("offset", fmt.Sprintf("%d", offset))
-
-		path := "/api/v1/prism/filevault?" + params.Encode()
-		type prismResponse struct {
-			Data []client.PrismFileVault `json:"data"`
-		}
-		var listResp prismResponse
-
-		err := d.client.DoRequest(ctx, "GET", path, nil, &listResp)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prism filevault, got error: %s", err))
-			return
-		}
-
-		all = append(all, listResp.Data...)
-
-		if !data.Limit.IsNull() && len(all) >= limit {
-			all = all[:limit]
-			break
-		}
-
-		if len(listResp.Data) < limit {
-			break
-		}
-		offset += len(listResp.Data)
+	all, err := readPrism[client.PrismFileVault](ctx, d.client, query, data.Limit, data.Offset)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prism filevault, got error: %s", err))
+		return
 	}
 
 	data.ID = types.StringValue("prism_filevault")
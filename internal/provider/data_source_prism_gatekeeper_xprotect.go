@@ -3,7 +3,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/url"
 
 	"github.com/MScottBlake/terraform-provider-iru/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -11,7 +10,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-var _ datasource.DataSource = &prismGatekeeperXProtectDataSource{}
+var (
+	_ datasource.DataSource                   = &prismGatekeeperXProtectDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &prismGatekeeperXProtectDataSource{}
+)
 
 func NewPrismGatekeeperXProtectDataSource() datasource.DataSource {
 	return &prismGatekeeperXProtectDataSource{}
@@ -25,6 +27,8 @@ type prismGatekeeperXProtectDataSourceModel struct {
 	ID      types.String                   `tfsdk:"id"`
 	Limit   types.Int64                    `tfsdk:"limit"`
 	Offset  types.Int64                    `tfsdk:"offset"`
+	Filter  types.Object                   `tfsdk:"filter"`
+	SortBy  types.String                   `tfsdk:"sort_by"`
 	Results []prismGatekeeperXProtectModel `tfsdk:"results"`
 }
 
@@ -58,6 +62,11 @@ func (d *prismGatekeeperXProtectDataSource) Schema(ctx context.Context, req data
 				Optional:            true,
 				MarkdownDescription: "Number of results to skip.",
 			},
+			"filter": prismFilterDataSourceAttribute(),
+			"sort_by": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: prismSortByDescription,
+			},
 			"results": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -84,6 +93,10 @@ func (d *prismGatekeeperXProtectDataSource) Configure(ctx context.Context, req d
 	d.client = req.ProviderData.(*client.Client)
 }
 
+func (d *prismGatekeeperXProtectDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validatePrismDataSourceConfig(ctx, "gatekeeper_and_xprotect", req.Config)...)
+}
+
 func (d *prismGatekeeperXProtectDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data prismGatekeeperXProtectDataSourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -91,61 +104,30 @@ func (d *prismGatekeeperXProtectDataSource) Read(ctx context.Context, req dataso
 		return
 	}
 
-	var all []client.PrismEntry
-	offset := 0
-	if !data.Offset.IsNull() {
-		offset = int(data.Offset.ValueInt64())
-	}
-	limit := 300
-	if !data.Limit.IsNull() {
-		limit = int(data.Limit.ValueInt64())
+	query, diags := newPrismQuery(ctx, "gatekeeper_and_xprotect", types.ListNull(types.StringType), types.ListNull(types.StringType), data.Filter, data.SortBy)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	for {
-		params := url.Values{}
-		params.Add("limit", fmt.Sprintf("%d", limit))
-		params.Add("offset", fmt.Sprintf("%d", offset))
-
-		path := "/api/v1/prism/gatekeeper_and_xprotect?" + params.Encode()
-		type prismResponse struct {
-			Data []client.PrismEntry `json:"data"`
-		}
-		var listResp prismResponse
-
-		err := d.client.DoRequest(ctx, "GET", path, nil, &listResp)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prism gatekeeper_xprotect, got error: %s", err))
-			return
-		}
-
-		all = append(all, listResp.Data...)
-
-		if !data.Limit.IsNull() && len(all) >= limit {
-			all = all[:limit]
-			break
-		}
-
-		if len(listResp.Data) < limit {
-			break
-		}
-		offset += len(listResp.Data)
+	all, err := readPrism[client.PrismEntry](ctx, d.client, query, data.Limit, data.Offset)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prism gatekeeper_xprotect, got error: %s", err))
+		return
 	}
 
 	data.ID = types.StringValue("prism_gatekeeper_xprotect")
 	data.Results = make([]prismGatekeeperXProtectModel, 0, len(all))
 	for _, item := range all {
-		gkStatus, _ := item["gatekeeper_status"].(bool)
-		trustedDevs, _ := item["trusted_developers"].(bool)
-
 		data.Results = append(data.Results, prismGatekeeperXProtectModel{
-			DeviceID:                  types.StringValue(fmt.Sprintf("%v", item["device_id"])),
-			DeviceName:                types.StringValue(fmt.Sprintf("%v", item["device__name"])),
-			SerialNumber:              types.StringValue(fmt.Sprintf("%v", item["serial_number"])),
-			GatekeeperStatus:          types.BoolValue(gkStatus),
-			TrustedDevelopers:         types.BoolValue(trustedDevs),
-			GatekeeperVersion:         types.StringValue(fmt.Sprintf("%v", item["gatekeeper_version"])),
-			XProtectVersion:           types.StringValue(fmt.Sprintf("%v", item["xprotect_version"])),
-			MalwareRemovalToolVersion: types.StringValue(fmt.Sprintf("%v", item["malware_removal_tool_version"])),
+			DeviceID:                  prismRowString(item, "device_id"),
+			DeviceName:                prismRowString(item, "device__name"),
+			SerialNumber:              prismRowString(item, "serial_number"),
+			GatekeeperStatus:          prismRowBool(item, "gatekeeper_status"),
+			TrustedDevelopers:         prismRowBool(item, "trusted_developers"),
+			GatekeeperVersion:         prismRowString(item, "gatekeeper_version"),
+			XProtectVersion:           prismRowString(item, "xprotect_version"),
+			MalwareRemovalToolVersion: prismRowString(item, "malware_removal_tool_version"),
 		})
 	}
 
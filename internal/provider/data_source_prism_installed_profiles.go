@@ -3,7 +3,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/url"
 
 	"github.com/MScottBlake/terraform-provider-iru/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -11,7 +10,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-var _ datasource.DataSource = &prismInstalledProfilesDataSource{}
+var (
+	_ datasource.DataSource                   = &prismInstalledProfilesDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &prismInstalledProfilesDataSource{}
+)
 
 func NewPrismInstalledProfilesDataSource() datasource.DataSource {
 	return &prismInstalledProfilesDataSource{}
@@ -25,6 +27,8 @@ type prismInstalledProfilesDataSourceModel struct {
 	ID      types.String                 `tfsdk:"id"`
 	Limit   types.Int64                  `tfsdk:"limit"`
 	Offset  types.Int64                  `tfsdk:"offset"`
+	Filter  types.Object                 `tfsdk:"filter"`
+	SortBy  types.String                 `tfsdk:"sort_by"`
 	Results []prismInstalledProfileModel `tfsdk:"results"`
 }
 
@@ -57,6 +61,11 @@ func (d *prismInstalledProfilesDataSource) Schema(ctx context.Context, req datas
 				Optional:            true,
 				MarkdownDescription: "Number of results to skip.",
 			},
+			"filter": prismFilterDataSourceAttribute(),
+			"sort_by": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: prismSortByDescription,
+			},
 			"results": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -82,6 +91,10 @@ func (d *prismInstalledProfilesDataSource) Configure(ctx context.Context, req da
 	d.client = req.ProviderData.(*client.Client)
 }
 
+func (d *prismInstalledProfilesDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validatePrismDataSourceConfig(ctx, "installed_profiles", req.Config)...)
+}
+
 func (d *prismInstalledProfilesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data prismInstalledProfilesDataSourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -89,59 +102,29 @@ func (d *prismInstalledProfilesDataSource) Read(ctx context.Context, req datasou
 		return
 	}
 
-	var all []client.PrismEntry
-	offset := 0
-	if !data.Offset.IsNull() {
-		offset = int(data.Offset.ValueInt64())
-	}
-	limit := 300
-	if !data.Limit.IsNull() {
-		limit = int(data.Limit.ValueInt64())
+	query, diags := newPrismQuery(ctx, "installed_profiles", types.ListNull(types.StringType), types.ListNull(types.StringType), data.Filter, data.SortBy)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	for {
-		params := url.Values{}
-		params.Add("limit", fmt.Sprintf("%d", limit))
-		params.Add("offset", fmt.Sprintf("%d", offset))
-
-		path := "/api/v1/prism/installed_profiles?" + params.Encode()
-		type prismResponse struct {
-			Data []client.PrismEntry `json:"data"`
-		}
-		var listResp prismResponse
-
-		err := d.client.DoRequest(ctx, "GET", path, nil, &listResp)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prism installed_profiles, got error: %s", err))
-			return
-		}
-
-		all = append(all, listResp.Data...)
-
-		if !data.Limit.IsNull() && len(all) >= limit {
-			all = all[:limit]
-			break
-		}
-
-		if len(listResp.Data) < limit {
-			break
-		}
-		offset += len(listResp.Data)
+	all, err := readPrism[client.PrismEntry](ctx, d.client, query, data.Limit, data.Offset)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prism installed_profiles, got error: %s", err))
+		return
 	}
 
 	data.ID = types.StringValue("prism_installed_profiles")
 	data.Results = make([]prismInstalledProfileModel, 0, len(all))
 	for _, item := range all {
-		managed, _ := item["managed"].(bool)
-
 		data.Results = append(data.Results, prismInstalledProfileModel{
-			DeviceID:           types.StringValue(fmt.Sprintf("%v", item["device_id"])),
-			DeviceName:         types.StringValue(fmt.Sprintf("%v", item["device__name"])),
-			SerialNumber:       types.StringValue(fmt.Sprintf("%v", item["serial_number"])),
-			ProfileDisplayName: types.StringValue(fmt.Sprintf("%v", item["profile_display_name"])),
-			PayloadIdentifier:  types.StringValue(fmt.Sprintf("%v", item["payload_identifier"])),
-			PayloadUUID:        types.StringValue(fmt.Sprintf("%v", item["payload_uuid"])),
-			Managed:            types.BoolValue(managed),
+			DeviceID:           prismRowString(item, "device_id"),
+			DeviceName:         prismRowString(item, "device__name"),
+			SerialNumber:       prismRowString(item, "serial_number"),
+			ProfileDisplayName: prismRowString(item, "profile_display_name"),
+			PayloadIdentifier:  prismRowString(item, "payload_identifier"),
+			PayloadUUID:        prismRowString(item, "payload_uuid"),
+			Managed:            prismRowBool(item, "managed"),
 		})
 	}
 
@@ -3,7 +3,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/url"
 
 	"github.com/MScottBlake/terraform-provider-iru/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -11,7 +10,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-var _ datasource.DataSource = &prismKernelExtensionsDataSource{}
+var (
+	_ datasource.DataSource                   = &prismKernelExtensionsDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &prismKernelExtensionsDataSource{}
+)
 
 func NewPrismKernelExtensionsDataSource() datasource.DataSource {
 	return &prismKernelExtensionsDataSource{}
@@ -25,6 +27,8 @@ type prismKernelExtensionsDataSourceModel struct {
 	ID      types.String                `tfsdk:"id"`
 	Limit   types.Int64                 `tfsdk:"limit"`
 	Offset  types.Int64                 `tfsdk:"offset"`
+	Filter  types.Object                `tfsdk:"filter"`
+	SortBy  types.String                `tfsdk:"sort_by"`
 	Results []prismKernelExtensionModel `tfsdk:"results"`
 }
 
@@ -56,6 +60,11 @@ func (d *prismKernelExtensionsDataSource) Schema(ctx context.Context, req dataso
 				Optional:            true,
 				MarkdownDescription: "Number of results to skip.",
 			},
+			"filter": prismFilterDataSourceAttribute(),
+			"sort_by": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: prismSortByDescription,
+			},
 			"results": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -80,6 +89,10 @@ func (d *prismKernelExtensionsDataSource) Configure(ctx context.Context, req dat
 	d.client = req.ProviderData.(*client.Client)
 }
 
+func (d *prismKernelExtensionsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validatePrismDataSourceConfig(ctx, "kernel_extensions", req.Config)...)
+}
+
 func (d *prismKernelExtensionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data prismKernelExtensionsDataSourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -87,56 +100,28 @@ func (d *prismKernelExtensionsDataSource) Read(ctx context.Context, req datasour
 		return
 	}
 
-	var all []client.PrismEntry
-	offset := 0
-	if !data.Offset.IsNull() {
-		offset = int(data.Offset.ValueInt64())
-	}
-	limit := 300
-	if !data.Limit.IsNull() {
-		limit = int(data.Limit.ValueInt64())
+	query, diags := newPrismQuery(ctx, "kernel_extensions", types.ListNull(types.StringType), types.ListNull(types.StringType), data.Filter, data.SortBy)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	for {
-		params := url.Values{}
-		params.Add("limit", fmt.Sprintf("%d", limit))
-		params.Add("offset", fmt.Sprintf("%d", offset))
-
-		path := "/api/v1/prism/kernel_extensions?" + params.Encode()
-		type prismResponse struct {
-			Data []client.PrismEntry `json:"data"`
-		}
-		var listResp prismResponse
-
-		err := d.client.DoRequest(ctx, "GET", path, nil, &listResp)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prism kernel_extensions, got error: %s", err))
-			return
-		}
-
-		all = append(all, listResp.Data...)
-
-		if !data.Limit.IsNull() && len(all) >= limit {
-			all = all[:limit]
-			break
-		}
-
-		if len(listResp.Data) < limit {
-			break
-		}
-		offset += len(listResp.Data)
+	all, err := readPrism[client.PrismEntry](ctx, d.client, query, data.Limit, data.Offset)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prism kernel_extensions, got error: %s", err))
+		return
 	}
 
 	data.ID = types.StringValue("prism_kernel_extensions")
 	data.Results = make([]prismKernelExtensionModel, 0, len(all))
 	for _, item := range all {
 		data.Results = append(data.Results, prismKernelExtensionModel{
-			DeviceID:     types.StringValue(fmt.Sprintf("%v", item["device_id"])),
-			DeviceName:   types.StringValue(fmt.Sprintf("%v", item["device__name"])),
-			SerialNumber: types.StringValue(fmt.Sprintf("%v", item["serial_number"])),
-			BundleID:     types.StringValue(fmt.Sprintf("%v", item["bundle_id"])),
-			Version:      types.StringValue(fmt.Sprintf("%v", item["version"])),
-			Path:         types.StringValue(fmt.Sprintf("%v", item["path"])),
+			DeviceID:     prismRowString(item, "device_id"),
+			DeviceName:   prismRowString(item, "device__name"),
+			SerialNumber: prismRowString(item, "serial_number"),
+			BundleID:     prismRowString(item, "bundle_id"),
+			Version:      prismRowString(item, "version"),
+			Path:         prismRowString(item, "path"),
 		})
 	}
 
@@ -3,7 +3,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/url"
 
 	"github.com/MScottBlake/terraform-provider-iru/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -11,7 +10,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-var _ datasource.DataSource = &prismLocalUsersDataSource{}
+var (
+	_ datasource.DataSource                   = &prismLocalUsersDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &prismLocalUsersDataSource{}
+)
 
 func NewPrismLocalUsersDataSource() datasource.DataSource {
 	return &prismLocalUsersDataSource{}
@@ -25,6 +27,8 @@ type prismLocalUsersDataSourceModel struct {
 	ID      types.String          `tfsdk:"id"`
 	Limit   types.Int64           `tfsdk:"limit"`
 	Offset  types.Int64           `tfsdk:"offset"`
+	Filter  types.Object          `tfsdk:"filter"`
+	SortBy  types.String          `tfsdk:"sort_by"`
 	Results []prismLocalUserModel `tfsdk:"results"`
 }
 
@@ -60,6 +64,11 @@ func (d *prismLocalUsersDataSource) Schema(ctx context.Context, req datasource.S
 				Optional:            true,
 				MarkdownDescription: "Number of results to skip.",
 			},
+			"filter": prismFilterDataSourceAttribute(),
+			"sort_by": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: prismSortByDescription,
+			},
 			"results": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -88,6 +97,10 @@ func (d *prismLocalUsersDataSource) Configure(ctx context.Context, req datasourc
 	d.client = req.ProviderData.(*client.Client)
 }
 
+func (d *prismLocalUsersDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validatePrismDataSourceConfig(ctx, "local_users", req.Config)...)
+}
+
 func (d *prismLocalUsersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data prismLocalUsersDataSourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -95,68 +108,32 @@ func (d *prismLocalUsersDataSource) Read(ctx context.Context, req datasource.Rea
 		return
 	}
 
-	var all []client.PrismEntry
-	offset := 0
-	if !data.Offset.IsNull() {
-		offset = int(data.Offset.ValueInt64())
-	}
-	limit := 300
-	if !data.Limit.IsNull() {
-		limit = int(data.Limit.ValueInt64())
+	query, diags := newPrismQuery(ctx, "local_users", types.ListNull(types.StringType), types.ListNull(types.StringType), data.Filter, data.SortBy)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	for {
-		params := url.Values{}
-		params.Add("limit", fmt.Sprintf("%d", limit))
-		params.Add("offset", fmt.Sprintf("%d", offset))
-
-		path := "/api/v1/prism/local_users?" + params.Encode()
-		type prismResponse struct {
-			Data []client.PrismEntry `json:"data"`
-		}
-		var listResp prismResponse
-
-		err := d.client.DoRequest(ctx, "GET", path, nil, &listResp)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prism local_users, got error: %s", err))
-			return
-		}
-
-		all = append(all, listResp.Data...)
-
-		if !data.Limit.IsNull() && len(all) >= limit {
-			all = all[:limit]
-			break
-		}
-
-		if len(listResp.Data) < limit {
-			break
-		}
-		offset += len(listResp.Data)
+	all, err := readPrism[client.PrismEntry](ctx, d.client, query, data.Limit, data.Offset)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prism local_users, got error: %s", err))
+		return
 	}
 
 	data.ID = types.StringValue("prism_local_users")
 	data.Results = make([]prismLocalUserModel, 0, len(all))
 	for _, item := range all {
-		username, _ := item["username"].(string)
-		fullName, _ := item["full_name"].(string)
-		userType, _ := item["type"].(string)
-		uid, _ := item["uid"].(float64)
-		loggedIn, _ := item["logged_in"].(bool)
-		hidden, _ := item["hidden_user"].(bool)
-		fvUser, _ := item["filevault_user"].(bool)
-
 		data.Results = append(data.Results, prismLocalUserModel{
-			DeviceID:      types.StringValue(fmt.Sprintf("%v", item["device_id"])),
-			DeviceName:    types.StringValue(fmt.Sprintf("%v", item["device__name"])),
-			SerialNumber:  types.StringValue(fmt.Sprintf("%v", item["serial_number"])),
-			Username:      types.StringValue(username),
-			FullName:      types.StringValue(fullName),
-			UserType:      types.StringValue(userType),
-			UID:           types.Int64Value(int64(uid)),
-			LoggedIn:      types.BoolValue(loggedIn),
-			HiddenUser:    types.BoolValue(hidden),
-			FileVaultUser: types.BoolValue(fvUser),
+			DeviceID:      prismRowString(item, "device_id"),
+			DeviceName:    prismRowString(item, "device__name"),
+			SerialNumber:  prismRowString(item, "serial_number"),
+			Username:      prismRowString(item, "username"),
+			FullName:      prismRowString(item, "full_name"),
+			UserType:      prismRowString(item, "type"),
+			UID:           prismRowInt64(item, "uid"),
+			LoggedIn:      prismRowBool(item, "logged_in"),
+			HiddenUser:    prismRowBool(item, "hidden_user"),
+			FileVaultUser: prismRowBool(item, "filevault_user"),
 		})
 	}
 
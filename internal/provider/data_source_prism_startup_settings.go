@@ -3,7 +3,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/url"
 
 	"github.com/MScottBlake/terraform-provider-iru/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -11,7 +10,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-var _ datasource.DataSource = &prismStartupSettingsDataSource{}
+var (
+	_ datasource.DataSource                   = &prismStartupSettingsDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &prismStartupSettingsDataSource{}
+)
 
 func NewPrismStartupSettingsDataSource() datasource.DataSource {
 	return &prismStartupSettingsDataSource{}
@@ -25,6 +27,8 @@ type prismStartupSettingsDataSourceModel struct {
 	ID      types.String               `tfsdk:"id"`
 	Limit   types.Int64                `tfsdk:"limit"`
 	Offset  types.Int64                `tfsdk:"offset"`
+	Filter  types.Object               `tfsdk:"filter"`
+	SortBy  types.String               `tfsdk:"sort_by"`
 	Results []prismStartupSettingModel `tfsdk:"results"`
 }
 
@@ -56,6 +60,11 @@ func (d *prismStartupSettingsDataSource) Schema(ctx context.Context, req datasou
 				Optional:            true,
 				MarkdownDescription: "Number of results to skip.",
 			},
+			"filter": prismFilterDataSourceAttribute(),
+			"sort_by": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: prismSortByDescription,
+			},
 			"results": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -80,6 +89,10 @@ func (d *prismStartupSettingsDataSource) Configure(ctx context.Context, req data
 	d.client = req.ProviderData.(*client.Client)
 }
 
+func (d *prismStartupSettingsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validatePrismDataSourceConfig(ctx, "startup_settings", req.Config)...)
+}
+
 func (d *prismStartupSettingsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data prismStartupSettingsDataSourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -87,60 +100,28 @@ func (d *prismStartupSettingsDataSource) Read(ctx context.Context, req datasourc
 		return
 	}
 
-	var all []client.PrismEntry
-	offset := 0
-	if !data.Offset.IsNull() {
-		offset = int(data.Offset.ValueInt64())
-	}
-	limit := 300
-	if !data.Limit.IsNull() {
-		limit = int(data.Limit.ValueInt64())
+	query, diags := newPrismQuery(ctx, "startup_settings", types.ListNull(types.StringType), types.ListNull(types.StringType), data.Filter, data.SortBy)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	for {
-		params := url.Values{}
-		params.Add("limit", fmt.Sprintf("%d", limit))
-		params.Add("offset", fmt.Sprintf("%d", offset))
-
-		path := "/api/v1/prism/startup_settings?" + params.Encode()
-		type prismResponse struct {
-			Data []client.PrismEntry `json:"data"`
-		}
-		var listResp prismResponse
-
-		err := d.client.DoRequest(ctx, "GET", path, nil, &listResp)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prism startup_settings, got error: %s", err))
-			return
-		}
-
-		all = append(all, listResp.Data...)
-
-		if !data.Limit.IsNull() && len(all) >= limit {
-			all = all[:limit]
-			break
-		}
-
-		if len(listResp.Data) < limit {
-			break
-		}
-		offset += len(listResp.Data)
+	all, err := readPrism[client.PrismEntry](ctx, d.client, query, data.Limit, data.Offset)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prism startup_settings, got error: %s", err))
+		return
 	}
 
 	data.ID = types.StringValue("prism_startup_settings")
 	data.Results = make([]prismStartupSettingModel, 0, len(all))
 	for _, item := range all {
-		sip, _ := item["sip"].(bool)
-		ssv, _ := item["ssv"].(bool)
-		secureBoot, _ := item["secure_boot_level"].(string)
-
 		data.Results = append(data.Results, prismStartupSettingModel{
-			DeviceID:     types.StringValue(fmt.Sprintf("%v", item["device_id"])),
-			DeviceName:   types.StringValue(fmt.Sprintf("%v", item["device__name"])),
-			SerialNumber: types.StringValue(fmt.Sprintf("%v", item["serial_number"])),
-			SIP:          types.BoolValue(sip),
-			SSV:          types.BoolValue(ssv),
-			SecureBoot:   types.StringValue(secureBoot),
+			DeviceID:     prismRowString(item, "device_id"),
+			DeviceName:   prismRowString(item, "device__name"),
+			SerialNumber: prismRowString(item, "serial_number"),
+			SIP:          prismRowBool(item, "sip"),
+			SSV:          prismRowBool(item, "ssv"),
+			SecureBoot:   prismRowString(item, "secure_boot_level"),
 		})
 	}
 
@@ -3,7 +3,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/url"
 
 	"github.com/MScottBlake/terraform-provider-iru/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -11,7 +10,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-var _ datasource.DataSource = &prismSystemExtensionsDataSource{}
+var (
+	_ datasource.DataSource                   = &prismSystemExtensionsDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &prismSystemExtensionsDataSource{}
+)
 
 func NewPrismSystemExtensionsDataSource() datasource.DataSource {
 	return &prismSystemExtensionsDataSource{}
@@ -25,6 +27,8 @@ type prismSystemExtensionsDataSourceModel struct {
 	ID      types.String                `tfsdk:"id"`
 	Limit   types.Int64                 `tfsdk:"limit"`
 	Offset  types.Int64                 `tfsdk:"offset"`
+	Filter  types.Object                `tfsdk:"filter"`
+	SortBy  types.String                `tfsdk:"sort_by"`
 	Results []prismSystemExtensionModel `tfsdk:"results"`
 }
 
@@ -59,6 +63,11 @@ func (d *prismSystemExtensionsDataSource) Schema(ctx context.Context, req dataso
 				Optional:            true,
 				MarkdownDescription: "Number of results to skip.",
 			},
+			"filter": prismFilterDataSourceAttribute(),
+			"sort_by": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: prismSortByDescription,
+			},
 			"results": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -86,6 +95,10 @@ func (d *prismSystemExtensionsDataSource) Configure(ctx context.Context, req dat
 	d.client = req.ProviderData.(*client.Client)
 }
 
+func (d *prismSystemExtensionsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validatePrismDataSourceConfig(ctx, "system_extensions", req.Config)...)
+}
+
 func (d *prismSystemExtensionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data prismSystemExtensionsDataSourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -93,66 +106,31 @@ func (d *prismSystemExtensionsDataSource) Read(ctx context.Context, req datasour
 		return
 	}
 
-	var all []client.PrismEntry
-	offset := 0
-	if !data.Offset.IsNull() {
-		offset = int(data.Offset.ValueInt64())
-	}
-	limit := 300
-	if !data.Limit.IsNull() {
-		limit = int(data.Limit.ValueInt64())
+	query, diags := newPrismQuery(ctx, "system_extensions", types.ListNull(types.StringType), types.ListNull(types.StringType), data.Filter, data.SortBy)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	for {
-		params := url.Values{}
-		params.Add("limit", fmt.Sprintf("%d", limit))
-		params.Add("offset", fmt.Sprintf("%d", offset))
-
-		path := "/api/v1/prism/system_extensions?" + params.Encode()
-		type prismResponse struct {
-			Data []client.PrismEntry `json:"data"`
-		}
-		var listResp prismResponse
-
-		err := d.client.DoRequest(ctx, "GET", path, nil, &listResp)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prism system_extensions, got error: %s", err))
-			return
-		}
-
-		all = append(all, listResp.Data...)
-
-		if !data.Limit.IsNull() && len(all) >= limit {
-			all = all[:limit]
-			break
-		}
-
-		if len(listResp.Data) < limit {
-			break
-		}
-		offset += len(listResp.Data)
+	all, err := readPrism[client.PrismEntry](ctx, d.client, query, data.Limit, data.Offset)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prism system_extensions, got error: %s", err))
+		return
 	}
 
 	data.ID = types.StringValue("prism_system_extensions")
 	data.Results = make([]prismSystemExtensionModel, 0, len(all))
 	for _, item := range all {
-		identifier, _ := item["identifier"].(string)
-		name, _ := item["name"].(string)
-		state, _ := item["state"].(string)
-		teamID, _ := item["team_id"].(string)
-		bundlePath, _ := item["bundle_path"].(string)
-		isMDM, _ := item["is_mdm_managed"].(bool)
-
 		data.Results = append(data.Results, prismSystemExtensionModel{
-			DeviceID:     types.StringValue(fmt.Sprintf("%v", item["device_id"])),
-			DeviceName:   types.StringValue(fmt.Sprintf("%v", item["device__name"])),
-			SerialNumber: types.StringValue(fmt.Sprintf("%v", item["serial_number"])),
-			Identifier:   types.StringValue(identifier),
-			Name:         types.StringValue(name),
-			State:        types.StringValue(state),
-			TeamID:       types.StringValue(teamID),
-			BundlePath:   types.StringValue(bundlePath),
-			IsMDMManaged: types.BoolValue(isMDM),
+			DeviceID:     prismRowString(item, "device_id"),
+			DeviceName:   prismRowString(item, "device__name"),
+			SerialNumber: prismRowString(item, "serial_number"),
+			Identifier:   prismRowString(item, "identifier"),
+			Name:         prismRowString(item, "name"),
+			State:        prismRowString(item, "state"),
+			TeamID:       prismRowString(item, "team_id"),
+			BundlePath:   prismRowString(item, "bundle_path"),
+			IsMDMManaged: prismRowBool(item, "is_mdm_managed"),
 		})
 	}
 
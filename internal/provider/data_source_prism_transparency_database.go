@@ -3,7 +3,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/url"
 
 	"github.com/MScottBlake/terraform-provider-iru/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -11,7 +10,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-var _ datasource.DataSource = &prismTransparencyDatabaseDataSource{}
+var (
+	_ datasource.DataSource                   = &prismTransparencyDatabaseDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &prismTransparencyDatabaseDataSource{}
+)
 
 func NewPrismTransparencyDatabaseDataSource() datasource.DataSource {
 	return &prismTransparencyDatabaseDataSource{}
@@ -25,6 +27,8 @@ type prismTransparencyDatabaseDataSourceModel struct {
 	ID      types.String                     `tfsdk:"id"`
 	Limit   types.Int64                      `tfsdk:"limit"`
 	Offset  types.Int64                      `tfsdk:"offset"`
+	Filter  types.Object                     `tfsdk:"filter"`
+	SortBy  types.String                     `tfsdk:"sort_by"`
 	Results []prismTransparencyDatabaseModel `tfsdk:"results"`
 }
 
@@ -57,6 +61,11 @@ func (d *prismTransparencyDatabaseDataSource) Schema(ctx context.Context, req da
 				Optional:            true,
 				MarkdownDescription: "Number of results to skip.",
 			},
+			"filter": prismFilterDataSourceAttribute(),
+			"sort_by": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: prismSortByDescription,
+			},
 			"results": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -82,6 +91,10 @@ func (d *prismTransparencyDatabaseDataSource) Configure(ctx context.Context, req
 	d.client = req.ProviderData.(*client.Client)
 }
 
+func (d *prismTransparencyDatabaseDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validatePrismDataSourceConfig(ctx, "transparency_database", req.Config)...)
+}
+
 func (d *prismTransparencyDatabaseDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data prismTransparencyDatabaseDataSourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -89,62 +102,29 @@ func (d *prismTransparencyDatabaseDataSource) Read(ctx context.Context, req data
 		return
 	}
 
-	var all []client.PrismEntry
-	offset := 0
-	if !data.Offset.IsNull() {
-		offset = int(data.Offset.ValueInt64())
-	}
-	limit := 300
-	if !data.Limit.IsNull() {
-		limit = int(data.Limit.ValueInt64())
+	query, diags := newPrismQuery(ctx, "transparency_database", types.ListNull(types.StringType), types.ListNull(types.StringType), data.Filter, data.SortBy)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	for {
-		params := url.Values{}
-		params.Add("limit", fmt.Sprintf("%d", limit))
-		params.Add("offset", fmt.Sprintf("%d", offset))
-
-		path := "/api/v1/prism/transparency_database?" + params.Encode()
-		type prismResponse struct {
-			Data []client.PrismEntry `json:"data"`
-		}
-		var listResp prismResponse
-
-		err := d.client.DoRequest(ctx, "GET", path, nil, &listResp)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prism transparency_database, got error: %s", err))
-			return
-		}
-
-		all = append(all, listResp.Data...)
-
-		if !data.Limit.IsNull() && len(all) >= limit {
-			all = all[:limit]
-			break
-		}
-
-		if len(listResp.Data) < limit {
-			break
-		}
-		offset += len(listResp.Data)
+	all, err := readPrism[client.PrismEntry](ctx, d.client, query, data.Limit, data.Offset)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prism transparency_database, got error: %s", err))
+		return
 	}
 
 	data.ID = types.StringValue("prism_transparency_database")
 	data.Results = make([]prismTransparencyDatabaseModel, 0, len(all))
 	for _, item := range all {
-		service, _ := item["service"].(string)
-		app, _ := item["application"].(string)
-		status, _ := item["status"].(string)
-		user, _ := item["local_user"].(string)
-
 		data.Results = append(data.Results, prismTransparencyDatabaseModel{
-			DeviceID:     types.StringValue(fmt.Sprintf("%v", item["device_id"])),
-			DeviceName:   types.StringValue(fmt.Sprintf("%v", item["device__name"])),
-			SerialNumber: types.StringValue(fmt.Sprintf("%v", item["serial_number"])),
-			Service:      types.StringValue(service),
-			Application:  types.StringValue(app),
-			Status:       types.StringValue(status),
-			LocalUser:    types.StringValue(user),
+			DeviceID:     prismRowString(item, "device_id"),
+			DeviceName:   prismRowString(item, "device__name"),
+			SerialNumber: prismRowString(item, "serial_number"),
+			Service:      prismRowString(item, "service"),
+			Application:  prismRowString(item, "application"),
+			Status:       prismRowString(item, "status"),
+			LocalUser:    prismRowString(item, "local_user"),
 		})
 	}
 
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &tagDataSource{}
+
+func NewTagDataSource() datasource.DataSource {
+	return &tagDataSource{}
+}
+
+type tagDataSource struct {
+	client *client.Client
+}
+
+type tagSingleDataSourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	NameRegex types.String `tfsdk:"name_regex"`
+}
+
+func (d *tagDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tag"
+}
+
+func (d *tagDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Get a specific tag, looked up by ID, by exact name or by name regular expression. Exactly one of `id`, `name` and `name_regex` must be set, and the lookup fails unless exactly one tag matches.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the Tag.",
+			},
+			"name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The exact name of the Tag.",
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "A regular expression matching the name of the Tag.",
+			},
+		},
+	}
+}
+
+func (d *tagDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.client = req.ProviderData.(*client.Client)
+}
+
+func (d *tagDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data tagSingleDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	lookup, diags := newNameLookup(data.ID, data.Name, data.NameRegex)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var tag client.Tag
+	if lookup != nil {
+		items := resultsPages[client.Tag](ctx, d.client, "/api/v1/tags", lookup.params())
+		tag, diags = findOne(lookup, "Tag", items, func(t client.Tag) string { return t.Name })
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.ID = types.StringValue(tag.ID)
+	} else {
+		err := d.client.DoRequest(ctx, "GET", "/api/v1/tags/"+data.ID.ValueString(), nil, &tag)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read tag, got error: %s", err))
+			return
+		}
+	}
+
+	data.Name = types.StringValue(tag.Name)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
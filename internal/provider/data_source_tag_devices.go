@@ -0,0 +1,235 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &tagDevicesDataSource{}
+
+func NewTagDevicesDataSource() datasource.DataSource {
+	return &tagDevicesDataSource{}
+}
+
+type tagDevicesDataSource struct {
+	client *client.Client
+}
+
+type tagDevicesDataSourceModel struct {
+	ID        types.String            `tfsdk:"id"`
+	Names     []types.String          `tfsdk:"names"`
+	Match     types.String            `tfsdk:"match"`
+	IDs       map[string]types.String `tfsdk:"ids"`
+	DeviceIDs []types.String          `tfsdk:"device_ids"`
+	Devices   []tagDeviceModel        `tfsdk:"devices"`
+}
+
+type tagDeviceModel struct {
+	ID           types.String   `tfsdk:"id"`
+	DeviceName   types.String   `tfsdk:"device_name"`
+	SerialNumber types.String   `tfsdk:"serial_number"`
+	Platform     types.String   `tfsdk:"platform"`
+	TagIDs       []types.String `tfsdk:"tag_ids"`
+}
+
+func (d *tagDevicesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tag_devices"
+}
+
+func (d *tagDevicesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resolves Tag names to IDs and lists the Devices carrying the tags.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"names": schema.ListAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The names of the tags. Every name must match an existing tag exactly.",
+			},
+			"match": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Whether to list devices carrying `any` of the tags or `all` of them. Defaults to `any`.",
+			},
+			"ids": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The tag IDs, keyed by tag name.",
+			},
+			"device_ids": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The sorted IDs of the matching devices.",
+			},
+			"devices": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The unique identifier for the Device.",
+						},
+						"device_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the Device.",
+						},
+						"serial_number": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The serial number of the Device.",
+						},
+						"platform": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The platform of the Device.",
+						},
+						"tag_ids": schema.ListAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "The IDs of the requested tags that the Device carries.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *tagDevicesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *tagDevicesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data tagDevicesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	match := data.Match.ValueString()
+	if match == "" {
+		match = "any"
+	}
+	if match != "any" && match != "all" {
+		resp.Diagnostics.AddAttributeError(path.Root("match"), "Invalid Match", fmt.Sprintf("`match` must be `any` or `all`, got %q.", match))
+		return
+	}
+
+	data.IDs = make(map[string]types.String, len(data.Names))
+	var tags []client.Tag
+	for _, name := range data.Names {
+		tag, found, err := findTagByName(ctx, d.client, name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read tags, got error: %s", err))
+			return
+		}
+		if !found {
+			resp.Diagnostics.AddAttributeError(path.Root("names"), "Tag Not Found", fmt.Sprintf("No tag is named %q.", name.ValueString()))
+			continue
+		}
+		data.IDs[tag.Name] = types.StringValue(tag.ID)
+		tags = append(tags, tag)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	devices, deviceTags, err := listTaggedDevices(ctx, d.client, tags)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list tagged devices, got error: %s", err))
+		return
+	}
+
+	deviceIDs := make([]string, 0, len(devices))
+	for id := range devices {
+		if match == "all" && len(deviceTags[id]) != len(tags) {
+			continue
+		}
+		deviceIDs = append(deviceIDs, id)
+	}
+	slices.Sort(deviceIDs)
+
+	data.ID = types.StringValue("tag_devices")
+	data.DeviceIDs = make([]types.String, 0, len(deviceIDs))
+	data.Devices = make([]tagDeviceModel, 0, len(deviceIDs))
+	for _, id := range deviceIDs {
+		device := devices[id]
+		tagIDs := make([]types.String, 0, len(deviceTags[id]))
+		for _, tagID := range deviceTags[id] {
+			tagIDs = append(tagIDs, types.StringValue(tagID))
+		}
+
+		data.DeviceIDs = append(data.DeviceIDs, types.StringValue(id))
+		data.Devices = append(data.Devices, tagDeviceModel{
+			ID:           types.StringValue(id),
+			DeviceName:   types.StringValue(device.DeviceName),
+			SerialNumber: types.StringValue(device.SerialNumber),
+			Platform:     types.StringValue(device.Platform),
+			TagIDs:       tagIDs,
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// listTaggedDevices returns the devices carrying any of the given tags, keyed
+// by device ID, along with the IDs of the given tags each device carries.
+// Devices are listed with a tag_id filter, but membership is decided by the
+// tags reported on each device, so a server that ignores the filter does not
+// put every device in every tag.
+func listTaggedDevices(ctx context.Context, c *client.Client, tags []client.Tag) (map[string]client.Device, map[string][]string, error) {
+	devices := make(map[string]client.Device)
+	deviceTags := make(map[string][]string)
+	for _, tag := range tags {
+		params := url.Values{}
+		params.Add("tag_id", tag.ID)
+		for device, err := range devicePages(ctx, c, params) {
+			if err != nil {
+				return nil, nil, fmt.Errorf("tag %s: %w", tag.Name, err)
+			}
+			if !slices.ContainsFunc(device.Tags, func(t client.Tag) bool { return t.ID == tag.ID }) {
+				continue
+			}
+			devices[device.ID] = device
+			if !slices.Contains(deviceTags[device.ID], tag.ID) {
+				deviceTags[device.ID] = append(deviceTags[device.ID], tag.ID)
+			}
+		}
+	}
+	return devices, deviceTags, nil
+}
+
+// findTagByName returns the tag with exactly the given name.
+func findTagByName(ctx context.Context, c *client.Client, name string) (client.Tag, bool, error) {
+	params := url.Values{}
+	params.Add("name", name)
+	for tag, err := range resultsPages[client.Tag](ctx, c, "/api/v1/tags", params) {
+		if err != nil {
+			return client.Tag{}, false, err
+		}
+		if tag.Name == name {
+			return tag, true, nil
+		}
+	}
+	return client.Tag{}, false, nil
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+)
+
+func TestListTaggedDevicesIgnoredFilter(t *testing.T) {
+	// The server ignores tag_id and returns every device.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/devices" {
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("offset") != "0" {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[
+			{"device_id": "1", "device_name": "Mac-1", "tags": [{"id": "tag-a", "name": "A"}, {"id": "tag-b", "name": "B"}]},
+			{"device_id": "2", "device_name": "Mac-2", "tags": [{"id": "tag-a", "name": "A"}]},
+			{"device_id": "3", "device_name": "Mac-3"}
+		]`))
+	}))
+	defer server.Close()
+
+	tags := []client.Tag{{ID: "tag-a", Name: "A"}, {ID: "tag-b", Name: "B"}}
+	devices, deviceTags, err := listTaggedDevices(context.Background(), client.NewClient(server.URL, "test-token"), tags)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(devices) != 2 {
+		t.Fatalf("Expected 2 tagged devices, got %v", devices)
+	}
+	if _, ok := devices["3"]; ok {
+		t.Error("Expected the untagged device to be left out")
+	}
+	if got := deviceTags["1"]; !slices.Equal(got, []string{"tag-a", "tag-b"}) {
+		t.Errorf("Expected Mac-1 to carry both tags, got %v", got)
+	}
+	if got := deviceTags["2"]; !slices.Equal(got, []string{"tag-a"}) {
+		t.Errorf("Expected Mac-2 to carry only tag-a, got %v", got)
+	}
+}
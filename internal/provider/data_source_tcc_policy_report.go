@@ -0,0 +1,408 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &tccPolicyReportDataSource{}
+
+func NewTCCPolicyReportDataSource() datasource.DataSource {
+	return &tccPolicyReportDataSource{}
+}
+
+type tccPolicyReportDataSource struct {
+	client *client.Client
+}
+
+type tccPolicyReportDataSourceModel struct {
+	ID                   types.String                       `tfsdk:"id"`
+	Allowlist            map[string]tccPolicyAllowlistModel `tfsdk:"allowlist"`
+	BlueprintIDs         types.List                         `tfsdk:"blueprint_ids"`
+	DeviceFamilies       types.List                         `tfsdk:"device_families"`
+	Compliant            types.Bool                         `tfsdk:"compliant"`
+	UnexpectedGrantCount types.Int64                        `tfsdk:"unexpected_grant_count"`
+	MissingGrantCount    types.Int64                        `tfsdk:"missing_grant_count"`
+	Devices              []tccPolicyDeviceModel             `tfsdk:"devices"`
+}
+
+type tccPolicyAllowlistModel struct {
+	BundleIDs types.List `tfsdk:"bundle_ids"`
+	TeamIDs   types.List `tfsdk:"team_ids"`
+}
+
+type tccPolicyDeviceModel struct {
+	DeviceID         types.String              `tfsdk:"device_id"`
+	DeviceName       types.String              `tfsdk:"device_name"`
+	SerialNumber     types.String              `tfsdk:"serial_number"`
+	UnexpectedGrants []tccUnexpectedGrantModel `tfsdk:"unexpected_grants"`
+	MissingGrants    []tccMissingGrantModel    `tfsdk:"missing_grants"`
+}
+
+type tccUnexpectedGrantModel struct {
+	Service     types.String `tfsdk:"service"`
+	Application types.String `tfsdk:"application"`
+	TeamID      types.String `tfsdk:"team_id"`
+	LocalUser   types.String `tfsdk:"local_user"`
+}
+
+type tccMissingGrantModel struct {
+	Service     types.String `tfsdk:"service"`
+	Application types.String `tfsdk:"application"`
+	Status      types.String `tfsdk:"status"`
+}
+
+func (d *tccPolicyReportDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tcc_policy_report"
+}
+
+func (d *tccPolicyReportDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Compare the privacy (TCC) grants reported by Prism against an allowlist. A grant is unexpected when an application holds an allowlisted service without being allowed to, and a grant is missing when an allowed application is on a device but does not hold the service. Team IDs are resolved from the system extensions installed on each device.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"allowlist": schema.MapNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "The applications allowed to hold each service, keyed by service. Services are matched ignoring case and the `kTCCService` prefix, so `SystemPolicyAllFiles` and `kTCCServiceSystemPolicyAllFiles` are the same. `full_disk_access` and `screen_recording` are accepted for `SystemPolicyAllFiles` and `ScreenCapture`. Grants for services that are not in the allowlist are ignored.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"bundle_ids": schema.ListAttribute{
+							Optional:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "The bundle IDs allowed to hold the service.",
+						},
+						"team_ids": schema.ListAttribute{
+							Optional:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "The developer team IDs whose system extensions are allowed to hold the service.",
+						},
+					},
+				},
+			},
+			"blueprint_ids": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Only report on devices in these blueprints.",
+			},
+			"device_families": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Only report on these device families, such as `Mac`.",
+			},
+			"compliant": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether no device has an unexpected or missing grant.",
+			},
+			"unexpected_grant_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of unexpected grants across all devices.",
+			},
+			"missing_grant_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of missing grants across all devices.",
+			},
+			"devices": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The devices with at least one unexpected or missing grant, ordered by device name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"device_id":     schema.StringAttribute{Computed: true},
+						"device_name":   schema.StringAttribute{Computed: true},
+						"serial_number": schema.StringAttribute{Computed: true},
+						"unexpected_grants": schema.ListNestedAttribute{
+							Computed:            true,
+							MarkdownDescription: "Grants held by applications that are not allowed to hold the service.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"service":     schema.StringAttribute{Computed: true},
+									"application": schema.StringAttribute{Computed: true},
+									"team_id": schema.StringAttribute{
+										Computed:            true,
+										MarkdownDescription: "The team ID of the application, when it is known from the device's system extensions.",
+									},
+									"local_user": schema.StringAttribute{Computed: true},
+								},
+							},
+						},
+						"missing_grants": schema.ListNestedAttribute{
+							Computed:            true,
+							MarkdownDescription: "Allowed applications on the device that do not hold the service.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"service":     schema.StringAttribute{Computed: true},
+									"application": schema.StringAttribute{Computed: true},
+									"status": schema.StringAttribute{
+										Computed:            true,
+										MarkdownDescription: "The status reported for the service, such as `denied`, or null when the application has never requested it.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *tccPolicyReportDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *tccPolicyReportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data tccPolicyReportDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy := tccPolicy{}
+	for service, entry := range data.Allowlist {
+		var allowed tccAllowed
+		var bundleIDs, teamIDs []string
+		resp.Diagnostics.Append(entry.BundleIDs.ElementsAs(ctx, &bundleIDs, false)...)
+		resp.Diagnostics.Append(entry.TeamIDs.ElementsAs(ctx, &teamIDs, false)...)
+		allowed.service = service
+		allowed.bundleIDs = map[string]bool{}
+		for _, bundleID := range bundleIDs {
+			allowed.bundleIDs[bundleID] = true
+		}
+		allowed.teamIDs = map[string]bool{}
+		for _, teamID := range teamIDs {
+			allowed.teamIDs[teamID] = true
+		}
+		policy[tccServiceKey(service)] = allowed
+	}
+
+	categories := []string{"transparency_database", "system_extensions"}
+	queries := make([]prismQuery, len(categories))
+	for i, category := range categories {
+		query, diags := newPrismQuery(ctx, category, data.BlueprintIDs, data.DeviceFamilies, types.ObjectNull(nil), types.StringNull())
+		resp.Diagnostics.Append(diags...)
+		queries[i] = query
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rows := make([][]client.PrismEntry, len(categories))
+	errs := make([]error, len(categories))
+	forEachConcurrently(len(categories), len(categories), func(i int) {
+		rows[i], errs[i] = readPrism[client.PrismEntry](ctx, d.client, queries[i], types.Int64Null(), types.Int64Null())
+	})
+	for i, err := range errs {
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prism %s, got error: %s", categories[i], err))
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue("tcc_policy_report")
+	data.Devices = policy.report(rows[0], rows[1])
+
+	var unexpected, missing int
+	for _, device := range data.Devices {
+		unexpected += len(device.UnexpectedGrants)
+		missing += len(device.MissingGrants)
+	}
+	data.Compliant = types.BoolValue(len(data.Devices) == 0)
+	data.UnexpectedGrantCount = types.Int64Value(int64(unexpected))
+	data.MissingGrantCount = types.Int64Value(int64(missing))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// tccServiceAliases maps friendly service names to their TCC service keys.
+var tccServiceAliases = map[string]string{
+	"fulldiskaccess":  "systempolicyallfiles",
+	"screenrecording": "screencapture",
+}
+
+// tccServiceKey normalizes a TCC service name, so that
+// kTCCServiceSystemPolicyAllFiles, SystemPolicyAllFiles and full_disk_access
+// compare equal.
+func tccServiceKey(service string) string {
+	key := strings.ToLower(service)
+	key = strings.TrimPrefix(key, "ktccservice")
+	key = strings.NewReplacer("_", "", "-", "", " ", "").Replace(key)
+	if alias, ok := tccServiceAliases[key]; ok {
+		return alias
+	}
+	return key
+}
+
+// tccGranted reports whether a transparency database status grants the
+// service.
+func tccGranted(status string) bool {
+	switch strings.ToLower(status) {
+	case "allowed", "granted", "authorized", "true", "yes", "1", "2":
+		return true
+	}
+	return false
+}
+
+type tccAllowed struct {
+	service   string
+	bundleIDs map[string]bool
+	teamIDs   map[string]bool
+}
+
+func (a tccAllowed) allows(application, teamID string) bool {
+	return a.bundleIDs[application] || (teamID != "" && a.teamIDs[teamID])
+}
+
+// tccPolicy holds the allowlist keyed by tccServiceKey.
+type tccPolicy map[string]tccAllowed
+
+// report diffs the transparency database against the policy and returns the
+// devices with unexpected or missing grants. Team IDs come from the system
+// extensions, and an application counts as present on a device when it has a
+// transparency database entry or a system extension there.
+func (p tccPolicy) report(grants, extensions []client.PrismEntry) []tccPolicyDeviceModel {
+	type device struct {
+		model tccPolicyDeviceModel
+		// present holds the applications seen on the device and their team IDs.
+		present map[string]string
+		// statuses holds the status of each service and application.
+		statuses map[[2]string]string
+	}
+	devices := map[string]*device{}
+	deviceFor := func(row client.PrismEntry, index int) *device {
+		key := prismDeviceKey(row, index)
+		dev, ok := devices[key]
+		if !ok {
+			dev = &device{
+				model: tccPolicyDeviceModel{
+					DeviceID:         prismRowString(row, "device_id"),
+					DeviceName:       prismRowString(row, "device__name"),
+					SerialNumber:     prismRowString(row, "serial_number"),
+					UnexpectedGrants: []tccUnexpectedGrantModel{},
+					MissingGrants:    []tccMissingGrantModel{},
+				},
+				present:  map[string]string{},
+				statuses: map[[2]string]string{},
+			}
+			devices[key] = dev
+		}
+		return dev
+	}
+
+	teamIDs := map[string]string{}
+	for i, row := range extensions {
+		identifier, _ := row["identifier"].(string)
+		teamID, _ := row["team_id"].(string)
+		if identifier == "" {
+			continue
+		}
+		if teamID != "" {
+			teamIDs[identifier] = teamID
+		}
+		deviceFor(row, len(grants)+i).present[identifier] = teamID
+	}
+
+	for i, row := range grants {
+		dev := deviceFor(row, i)
+		application, _ := row["application"].(string)
+		service, _ := row["service"].(string)
+		status, _ := row["status"].(string)
+		if _, ok := dev.present[application]; !ok {
+			dev.present[application] = teamIDs[application]
+		}
+
+		allowed, ok := p[tccServiceKey(service)]
+		if !ok {
+			continue
+		}
+		key := [2]string{allowed.service, application}
+		if _, ok := dev.statuses[key]; !ok || tccGranted(status) {
+			dev.statuses[key] = status
+		}
+		if tccGranted(status) && !allowed.allows(application, teamIDs[application]) {
+			grant := tccUnexpectedGrantModel{
+				Service:     types.StringValue(service),
+				Application: types.StringValue(application),
+				TeamID:      types.StringNull(),
+				LocalUser:   prismRowString(row, "local_user"),
+			}
+			if teamID, ok := teamIDs[application]; ok {
+				grant.TeamID = types.StringValue(teamID)
+			}
+			dev.model.UnexpectedGrants = append(dev.model.UnexpectedGrants, grant)
+		}
+	}
+
+	services := make([]string, 0, len(p))
+	for key := range p {
+		services = append(services, key)
+	}
+	sort.Strings(services)
+
+	var report []tccPolicyDeviceModel
+	for _, dev := range devices {
+		applications := make([]string, 0, len(dev.present))
+		for application := range dev.present {
+			applications = append(applications, application)
+		}
+		sort.Strings(applications)
+
+		for _, key := range services {
+			allowed := p[key]
+			for _, application := range applications {
+				if !allowed.allows(application, dev.present[application]) {
+					continue
+				}
+				status, requested := dev.statuses[[2]string{allowed.service, application}]
+				if requested && tccGranted(status) {
+					continue
+				}
+				missing := tccMissingGrantModel{
+					Service:     types.StringValue(allowed.service),
+					Application: types.StringValue(application),
+					Status:      types.StringNull(),
+				}
+				if requested {
+					missing.Status = types.StringValue(status)
+				}
+				dev.model.MissingGrants = append(dev.model.MissingGrants, missing)
+			}
+		}
+
+		if len(dev.model.UnexpectedGrants) > 0 || len(dev.model.MissingGrants) > 0 {
+			report = append(report, dev.model)
+		}
+	}
+	sort.Slice(report, func(i, j int) bool {
+		if a, b := report[i].DeviceName.ValueString(), report[j].DeviceName.ValueString(); a != b {
+			return a < b
+		}
+		return report[i].DeviceID.ValueString() < report[j].DeviceID.ValueString()
+	})
+	if report == nil {
+		report = []tccPolicyDeviceModel{}
+	}
+	return report
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+)
+
+func TestTCCServiceKey(t *testing.T) {
+	for _, service := range []string{"kTCCServiceSystemPolicyAllFiles", "SystemPolicyAllFiles", "full_disk_access", "Full Disk Access"} {
+		if got := tccServiceKey(service); got != "systempolicyallfiles" {
+			t.Errorf("Expected %q to normalize to systempolicyallfiles, got %q", service, got)
+		}
+	}
+	if got := tccServiceKey("screen_recording"); got != tccServiceKey("kTCCServiceScreenCapture") {
+		t.Errorf("Expected screen_recording to match ScreenCapture, got %q", got)
+	}
+}
+
+func TestTCCPolicyReport(t *testing.T) {
+	policy := tccPolicy{
+		tccServiceKey("full_disk_access"): {
+			service:   "full_disk_access",
+			bundleIDs: map[string]bool{"com.example.backup": true},
+			teamIDs:   map[string]bool{"X9E956P446": true},
+		},
+		tccServiceKey("Accessibility"): {
+			service:   "Accessibility",
+			bundleIDs: map[string]bool{},
+			teamIDs:   map[string]bool{},
+		},
+	}
+	grants := []client.PrismEntry{
+		{"device_id": "1", "device__name": "Mac-1", "service": "kTCCServiceSystemPolicyAllFiles", "application": "com.example.backup", "status": "allowed"},
+		{"device_id": "1", "device__name": "Mac-1", "service": "kTCCServiceSystemPolicyAllFiles", "application": "com.crowdstrike.falcon.Agent", "status": "allowed"},
+		{"device_id": "1", "device__name": "Mac-1", "service": "kTCCServiceCamera", "application": "us.zoom.xos", "status": "allowed"},
+		{"device_id": "2", "device__name": "Mac-2", "service": "kTCCServiceSystemPolicyAllFiles", "application": "com.example.backup", "status": "denied"},
+		{"device_id": "2", "device__name": "Mac-2", "service": "kTCCServiceAccessibility", "application": "com.example.keylogger", "status": "allowed", "local_user": "alice"},
+		{"device_id": "3", "device__name": "Mac-3", "service": "kTCCServiceSystemPolicyAllFiles", "application": "com.example.backup", "status": "allowed"},
+	}
+	extensions := []client.PrismEntry{
+		{"device_id": "1", "device__name": "Mac-1", "identifier": "com.crowdstrike.falcon.Agent", "team_id": "X9E956P446"},
+		{"device_id": "3", "device__name": "Mac-3", "identifier": "com.crowdstrike.falcon.Agent", "team_id": "X9E956P446"},
+	}
+
+	report := policy.report(grants, extensions)
+	if len(report) != 2 {
+		t.Fatalf("Expected 2 noncompliant devices, got %v", report)
+	}
+
+	mac2 := report[0]
+	if mac2.DeviceName.ValueString() != "Mac-2" || len(mac2.UnexpectedGrants) != 1 || len(mac2.MissingGrants) != 1 {
+		t.Fatalf("Expected Mac-2 to have 1 unexpected and 1 missing grant, got %v", mac2)
+	}
+	if got := mac2.UnexpectedGrants[0]; got.Application.ValueString() != "com.example.keylogger" || got.LocalUser.ValueString() != "alice" || !got.TeamID.IsNull() {
+		t.Errorf("Expected the keylogger's Accessibility grant to be unexpected, got %v", got)
+	}
+	if got := mac2.MissingGrants[0]; got.Application.ValueString() != "com.example.backup" || got.Status.ValueString() != "denied" {
+		t.Errorf("Expected the denied backup grant to be missing, got %v", got)
+	}
+
+	mac3 := report[1]
+	if mac3.DeviceName.ValueString() != "Mac-3" || len(mac3.UnexpectedGrants) != 0 || len(mac3.MissingGrants) != 1 {
+		t.Fatalf("Expected Mac-3 to have 1 missing grant, got %v", mac3)
+	}
+	if got := mac3.MissingGrants[0]; got.Application.ValueString() != "com.crowdstrike.falcon.Agent" || !got.Status.IsNull() {
+		t.Errorf("Expected the allowed team's extension to be missing Full Disk Access, got %v", got)
+	}
+}
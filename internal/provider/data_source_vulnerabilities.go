@@ -89,30 +89,10 @@ func (d *vulnerabilitiesDataSource) Configure(ctx context.Context, req datasourc
 func (d *vulnerabilitiesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data vulnerabilitiesDataSourceModel
 
-	var all []client.Vulnerability
-	page := 1
-	size := 50
-
-	for {
-		type vulnerabilityResponse struct {
-			Results []client.Vulnerability `json:"results"`
-			Next    string                 `json:"next"`
-		}
-		var listResp vulnerabilityResponse
-
-		path := fmt.Sprintf("/api/v1/vulnerability-management/vulnerabilities?size=%d&page=%d", size, page)
-		err := d.client.DoRequest(ctx, "GET", path, nil, &listResp)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read vulnerabilities, got error: %s", err))
-			return
-		}
-
-		all = append(all, listResp.Results...)
-
-		if listResp.Next == "" || len(listResp.Results) < size {
-			break
-		}
-		page++
+	all, err := listVulnerabilityPages[client.Vulnerability](ctx, d.client, "/api/v1/vulnerability-management/vulnerabilities")
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read vulnerabilities, got error: %s", err))
+		return
 	}
 
 	for _, item := range all {
@@ -26,10 +26,20 @@ type vulnerabilityDetectionsDataSourceModel struct {
 }
 
 type vulnerabilityDetectionModel struct {
-	CVEID        types.String `tfsdk:"cve_id"`
-	DeviceName   types.String `tfsdk:"device_name"`
-	SerialNumber types.String `tfsdk:"serial_number"`
-	Status       types.String `tfsdk:"status"`
+	CVEID               types.String  `tfsdk:"cve_id"`
+	DeviceID            types.String  `tfsdk:"device_id"`
+	DeviceName          types.String  `tfsdk:"device_name"`
+	SerialNumber        types.String  `tfsdk:"serial_number"`
+	BlueprintID         types.String  `tfsdk:"blueprint_id"`
+	BlueprintName       types.String  `tfsdk:"blueprint_name"`
+	SoftwareName        types.String  `tfsdk:"software_name"`
+	SoftwareVersion     types.String  `tfsdk:"software_version"`
+	BundleID            types.String  `tfsdk:"bundle_id"`
+	Severity            types.String  `tfsdk:"severity"`
+	CVSSScore           types.Float64 `tfsdk:"cvss_score"`
+	FirstDetectionDate  types.String  `tfsdk:"first_detection_date"`
+	LatestDetectionDate types.String  `tfsdk:"latest_detection_date"`
+	Status              types.String  `tfsdk:"status"`
 }
 
 func (d *vulnerabilityDetectionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -47,10 +57,32 @@ func (d *vulnerabilityDetectionsDataSource) Schema(ctx context.Context, req data
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
-						"cve_id":        schema.StringAttribute{Computed: true},
-						"device_name":   schema.StringAttribute{Computed: true},
-						"serial_number": schema.StringAttribute{Computed: true},
-						"status":        schema.StringAttribute{Computed: true},
+						"cve_id":         schema.StringAttribute{Computed: true},
+						"device_id":      schema.StringAttribute{Computed: true},
+						"device_name":    schema.StringAttribute{Computed: true},
+						"serial_number":  schema.StringAttribute{Computed: true},
+						"blueprint_id":   schema.StringAttribute{Computed: true},
+						"blueprint_name": schema.StringAttribute{Computed: true},
+						"software_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the vulnerable software.",
+						},
+						"software_version": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The installed version of the vulnerable software.",
+						},
+						"bundle_id":  schema.StringAttribute{Computed: true},
+						"severity":   schema.StringAttribute{Computed: true},
+						"cvss_score": schema.Float64Attribute{Computed: true},
+						"first_detection_date": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "When the vulnerability was first detected on the device, in RFC 3339 format.",
+						},
+						"latest_detection_date": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "When the vulnerability was last detected on the device, in RFC 3339 format.",
+						},
+						"status": schema.StringAttribute{Computed: true},
 					},
 				},
 			},
@@ -68,24 +100,35 @@ func (d *vulnerabilityDetectionsDataSource) Configure(ctx context.Context, req d
 func (d *vulnerabilityDetectionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data vulnerabilityDetectionsDataSourceModel
 
-	var response struct {
-		Results []map[string]interface{} `json:"results"`
-	}
-	err := d.client.DoRequest(ctx, "GET", "/api/v1/vulnerability-management/detections", nil, &response)
+	detections, err := listVulnerabilityPages[client.VulnerabilityDetection](ctx, d.client, "/api/v1/vulnerability-management/detections")
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read vulnerability detections, got error: %s", err))
 		return
 	}
 
 	data.ID = types.StringValue("vulnerability_detections")
-	for _, item := range response.Results {
-		data.Results = append(data.Results, vulnerabilityDetectionModel{
-			CVEID:        types.StringValue(fmt.Sprintf("%v", item["cve_id"])),
-			DeviceName:   types.StringValue(fmt.Sprintf("%v", item["device_name"])),
-			SerialNumber: types.StringValue(fmt.Sprintf("%v", item["device_serial_number"])),
-			Status:       types.StringValue(fmt.Sprintf("%v", item["status"])),
-		})
+	for _, item := range detections {
+		data.Results = append(data.Results, newVulnerabilityDetectionModel(item))
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+func newVulnerabilityDetectionModel(item client.VulnerabilityDetection) vulnerabilityDetectionModel {
+	return vulnerabilityDetectionModel{
+		CVEID:               types.StringValue(item.CVEID),
+		DeviceID:            types.StringPointerValue(item.DeviceID),
+		DeviceName:          types.StringPointerValue(item.DeviceName),
+		SerialNumber:        types.StringPointerValue(item.DeviceSerialNumber),
+		BlueprintID:         types.StringPointerValue(item.BlueprintID),
+		BlueprintName:       types.StringPointerValue(item.BlueprintName),
+		SoftwareName:        types.StringPointerValue(item.Name),
+		SoftwareVersion:     types.StringPointerValue(item.Version),
+		BundleID:            types.StringPointerValue(item.BundleID),
+		Severity:            types.StringPointerValue(item.Severity),
+		CVSSScore:           types.Float64PointerValue(item.CVSSScore),
+		FirstDetectionDate:  timestampValue(types.StringPointerValue(item.FirstDetectionDate)),
+		LatestDetectionDate: timestampValue(types.StringPointerValue(item.LatestDetectionDate)),
+		Status:              types.StringPointerValue(item.Status),
+	}
+}
@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+)
+
+func TestNewVulnerabilityDetectionModel(t *testing.T) {
+	var detections []client.VulnerabilityDetection
+	err := json.Unmarshal([]byte(`[
+		{"cve_id": "CVE-2024-0001", "device_name": "Mac-1", "cvss_score": 9.8, "cvss_severity": "Critical", "first_detection_date": "2024-03-01 12:30:00"},
+		{"cve_id": "CVE-2024-0002", "device_name": null}
+	]`), &detections)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	first := newVulnerabilityDetectionModel(detections[0])
+	if first.CVSSScore.ValueFloat64() != 9.8 || first.Severity.ValueString() != "Critical" {
+		t.Errorf("Expected the score and severity to be kept, got %v", first)
+	}
+	if first.FirstDetectionDate.ValueString() != "2024-03-01T12:30:00Z" {
+		t.Errorf("Expected the first detection date normalized, got %v", first.FirstDetectionDate)
+	}
+	if !first.SerialNumber.IsNull() || !first.Status.IsNull() {
+		t.Errorf("Expected absent fields to be null, got %v", first)
+	}
+
+	second := newVulnerabilityDetectionModel(detections[1])
+	if !second.DeviceName.IsNull() || !second.CVSSScore.IsNull() || !second.FirstDetectionDate.IsNull() {
+		t.Errorf("Expected null and absent fields to be null, got %v", second)
+	}
+}
@@ -72,7 +72,7 @@ func (d *vulnerabilityDevicesDataSource) Read(ctx context.Context, req datasourc
 	}
 
 	var response struct {
-		Results []map[string]interface{} `json:"results"`
+		Results []client.VulnerableDevice `json:"results"`
 	}
 	err := d.client.DoRequest(ctx, "GET", fmt.Sprintf("/api/v1/vulnerability-management/vulnerabilities/%s/devices", data.CVEID.ValueString()), nil, &response)
 	if err != nil {
@@ -82,9 +82,9 @@ func (d *vulnerabilityDevicesDataSource) Read(ctx context.Context, req datasourc
 
 	for _, item := range response.Results {
 		data.Devices = append(data.Devices, vulnerabilityDeviceModel{
-			DeviceID:     types.StringValue(fmt.Sprintf("%v", item["device_id"])),
-			Name:         types.StringValue(fmt.Sprintf("%v", item["name"])),
-			SerialNumber: types.StringValue(fmt.Sprintf("%v", item["serial_number"])),
+			DeviceID:     types.StringPointerValue(item.DeviceID),
+			Name:         types.StringPointerValue(item.Name),
+			SerialNumber: types.StringPointerValue(item.SerialNumber),
 		})
 	}
 
@@ -70,7 +70,7 @@ func (d *vulnerabilitySoftwareDataSource) Read(ctx context.Context, req datasour
 	}
 
 	var response struct {
-		Results []map[string]interface{} `json:"results"`
+		Results []client.VulnerableSoftware `json:"results"`
 	}
 	err := d.client.DoRequest(ctx, "GET", fmt.Sprintf("/api/v1/vulnerability-management/vulnerabilities/%s/software", data.CVEID.ValueString()), nil, &response)
 	if err != nil {
@@ -80,8 +80,8 @@ func (d *vulnerabilitySoftwareDataSource) Read(ctx context.Context, req datasour
 
 	for _, item := range response.Results {
 		data.Software = append(data.Software, vulnerabilitySoftwareModel{
-			Name:     types.StringValue(fmt.Sprintf("%v", item["name"])),
-			BundleID: types.StringValue(fmt.Sprintf("%v", item["bundle_id"])),
+			Name:     types.StringPointerValue(item.Name),
+			BundleID: types.StringPointerValue(item.BundleID),
 		})
 	}
 
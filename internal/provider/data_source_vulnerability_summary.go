@@ -0,0 +1,503 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource                   = &vulnerabilitySummaryDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &vulnerabilitySummaryDataSource{}
+)
+
+// defaultTopSoftwareLimit is the number of software entries returned in
+// top_software when top_software_limit is not set.
+const defaultTopSoftwareLimit = 10
+
+// vulnerabilityThresholdExampleLimit caps the number of CVE IDs named in a
+// threshold error.
+const vulnerabilityThresholdExampleLimit = 10
+
+func NewVulnerabilitySummaryDataSource() datasource.DataSource {
+	return &vulnerabilitySummaryDataSource{now: time.Now}
+}
+
+type vulnerabilitySummaryDataSource struct {
+	client *client.Client
+	now    func() time.Time
+}
+
+type vulnerabilitySummaryDataSourceModel struct {
+	ID                     types.String                         `tfsdk:"id"`
+	MaxCritical            types.Int64                          `tfsdk:"max_critical"`
+	MaxHigh                types.Int64                          `tfsdk:"max_high"`
+	TopSoftwareLimit       types.Int64                          `tfsdk:"top_software_limit"`
+	TotalVulnerabilities   types.Int64                          `tfsdk:"total_vulnerabilities"`
+	TotalDetections        types.Int64                          `tfsdk:"total_detections"`
+	AffectedDevices        types.Int64                          `tfsdk:"affected_devices"`
+	CriticalCount          types.Int64                          `tfsdk:"critical_count"`
+	HighCount              types.Int64                          `tfsdk:"high_count"`
+	SeverityCounts         map[string]types.Int64               `tfsdk:"severity_counts"`
+	CVSSRangeCounts        map[string]types.Int64               `tfsdk:"cvss_range_counts"`
+	OldestFirstDetection   types.String                         `tfsdk:"oldest_first_detection"`
+	OldestDetectionAgeDays types.Int64                          `tfsdk:"oldest_detection_age_days"`
+	TopSoftware            []vulnerabilitySoftwareSummaryModel  `tfsdk:"top_software"`
+	Blueprints             []vulnerabilityBlueprintSummaryModel `tfsdk:"blueprints"`
+}
+
+type vulnerabilitySoftwareSummaryModel struct {
+	Name               types.String `tfsdk:"name"`
+	VulnerabilityCount types.Int64  `tfsdk:"vulnerability_count"`
+	DeviceCount        types.Int64  `tfsdk:"device_count"`
+	CriticalCount      types.Int64  `tfsdk:"critical_count"`
+	HighCount          types.Int64  `tfsdk:"high_count"`
+}
+
+type vulnerabilityBlueprintSummaryModel struct {
+	BlueprintID        types.String `tfsdk:"blueprint_id"`
+	BlueprintName      types.String `tfsdk:"blueprint_name"`
+	VulnerabilityCount types.Int64  `tfsdk:"vulnerability_count"`
+	DeviceCount        types.Int64  `tfsdk:"device_count"`
+	CriticalCount      types.Int64  `tfsdk:"critical_count"`
+	HighCount          types.Int64  `tfsdk:"high_count"`
+}
+
+func (d *vulnerabilitySummaryDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vulnerability_summary"
+}
+
+func (d *vulnerabilitySummaryDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	groupAttributes := func(kind string) map[string]schema.Attribute {
+		return map[string]schema.Attribute{
+			"vulnerability_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: fmt.Sprintf("The number of distinct vulnerabilities detected in this %s.", kind),
+			},
+			"device_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: fmt.Sprintf("The number of distinct devices with a vulnerability detected in this %s.", kind),
+			},
+			"critical_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of those vulnerabilities that are critical.",
+			},
+			"high_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of those vulnerabilities that are high.",
+			},
+		}
+	}
+	software := groupAttributes("software")
+	software["name"] = schema.StringAttribute{Computed: true}
+	blueprint := groupAttributes("blueprint")
+	blueprint["blueprint_id"] = schema.StringAttribute{Computed: true}
+	blueprint["blueprint_name"] = schema.StringAttribute{Computed: true}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Summarize the fleet's vulnerability posture from Vulnerability Management, by severity, CVSS range, software and blueprint. Set `max_critical` or `max_high` to fail the plan when there are more vulnerabilities of that severity, for example to block a CI pipeline. A vulnerability without a severity is classified by its CVSS score.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"max_critical": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Return an error diagnostic when there are more critical vulnerabilities than this.",
+			},
+			"max_high": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Return an error diagnostic when there are more high vulnerabilities than this.",
+			},
+			"top_software_limit": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("The number of entries in `top_software`. Defaults to `%d`.", defaultTopSoftwareLimit),
+			},
+			"total_vulnerabilities": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of vulnerabilities.",
+			},
+			"total_detections": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of detections of a vulnerability in a piece of software on a device.",
+			},
+			"affected_devices": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of distinct devices with at least one detection.",
+			},
+			"critical_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of critical vulnerabilities.",
+			},
+			"high_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of high vulnerabilities.",
+			},
+			"severity_counts": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.Int64Type,
+				MarkdownDescription: "The number of vulnerabilities by severity: `critical`, `high`, `medium`, `low` and `none`. Other severities reported by the API are added in lower case.",
+			},
+			"cvss_range_counts": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.Int64Type,
+				MarkdownDescription: "The number of vulnerabilities by CVSS score range: `0.0-3.9`, `4.0-6.9`, `7.0-8.9` and `9.0-10.0`. Vulnerabilities without a score are not counted.",
+			},
+			"oldest_first_detection": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The earliest first detection date of any vulnerability, in RFC 3339 format. Null when nothing has been detected.",
+			},
+			"oldest_detection_age_days": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of whole days since `oldest_first_detection`.",
+			},
+			"top_software": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The software affecting the most devices, ordered by device count and then vulnerability count.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: software,
+				},
+			},
+			"blueprints": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The detections grouped by blueprint, ordered by blueprint name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: blueprint,
+				},
+			},
+		},
+	}
+}
+
+func (d *vulnerabilitySummaryDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *vulnerabilitySummaryDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data vulnerabilitySummaryDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for name, value := range map[string]types.Int64{
+		"max_critical":       data.MaxCritical,
+		"max_high":           data.MaxHigh,
+		"top_software_limit": data.TopSoftwareLimit,
+	} {
+		if !value.IsNull() && !value.IsUnknown() && value.ValueInt64() < 0 {
+			resp.Diagnostics.AddAttributeError(path.Root(name), "Invalid Attribute Value", fmt.Sprintf("`%s` must not be negative.", name))
+		}
+	}
+}
+
+func (d *vulnerabilitySummaryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data vulnerabilitySummaryDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var vulnerabilities []client.Vulnerability
+	var detections []client.VulnerabilityDetection
+	errs := make([]error, 2)
+	forEachConcurrently(2, 2, func(i int) {
+		if i == 0 {
+			vulnerabilities, errs[i] = listVulnerabilityPages[client.Vulnerability](ctx, d.client, "/api/v1/vulnerability-management/vulnerabilities")
+		} else {
+			detections, errs[i] = listVulnerabilityPages[client.VulnerabilityDetection](ctx, d.client, "/api/v1/vulnerability-management/detections")
+		}
+	})
+	if errs[0] != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read vulnerabilities, got error: %s", errs[0]))
+	}
+	if errs[1] != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read vulnerability detections, got error: %s", errs[1]))
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := defaultTopSoftwareLimit
+	if !data.TopSoftwareLimit.IsNull() {
+		limit = int(data.TopSoftwareLimit.ValueInt64())
+	}
+	summary := summarizeVulnerabilities(vulnerabilities, detections)
+
+	data.ID = types.StringValue("vulnerability_summary")
+	data.TotalVulnerabilities = types.Int64Value(int64(summary.total))
+	data.TotalDetections = types.Int64Value(int64(len(detections)))
+	data.AffectedDevices = types.Int64Value(int64(summary.affectedDevices))
+	data.CriticalCount = types.Int64Value(int64(len(summary.bySeverity["critical"])))
+	data.HighCount = types.Int64Value(int64(len(summary.bySeverity["high"])))
+	data.SeverityCounts = map[string]types.Int64{}
+	for severity, cves := range summary.bySeverity {
+		data.SeverityCounts[severity] = types.Int64Value(int64(len(cves)))
+	}
+	data.CVSSRangeCounts = map[string]types.Int64{}
+	for cvssRange, count := range summary.byCVSSRange {
+		data.CVSSRangeCounts[cvssRange] = types.Int64Value(int64(count))
+	}
+	data.OldestFirstDetection = types.StringNull()
+	data.OldestDetectionAgeDays = types.Int64Null()
+	if !summary.oldest.IsZero() {
+		data.OldestFirstDetection = types.StringValue(summary.oldest.Format(time.RFC3339))
+		data.OldestDetectionAgeDays = types.Int64Value(int64(math.Floor(d.now().Sub(summary.oldest).Hours() / 24)))
+	}
+	data.TopSoftware = summary.software
+	if len(data.TopSoftware) > limit {
+		data.TopSoftware = data.TopSoftware[:limit]
+	}
+	data.Blueprints = summary.blueprints
+
+	for _, threshold := range []struct {
+		severity string
+		max      types.Int64
+	}{
+		{"critical", data.MaxCritical},
+		{"high", data.MaxHigh},
+	} {
+		cves := summary.bySeverity[threshold.severity]
+		if threshold.max.IsNull() || int64(len(cves)) <= threshold.max.ValueInt64() {
+			continue
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_"+threshold.severity),
+			"Vulnerability Threshold Exceeded",
+			fmt.Sprintf("Found %d %s vulnerabilities, more than the maximum of %d: %s", len(cves), threshold.severity, threshold.max.ValueInt64(), vulnerabilityExamples(cves)),
+		)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// vulnerabilitySeverities are the severities always present in
+// severity_counts, from most to least severe.
+var vulnerabilitySeverities = []string{"critical", "high", "medium", "low", "none"}
+
+// vulnerabilitySeverity returns the lower-case severity of a vulnerability,
+// classifying it by CVSS score when the severity is missing.
+func vulnerabilitySeverity(severity string, score *float64) string {
+	if severity != "" {
+		return strings.ToLower(severity)
+	}
+	if score == nil {
+		return "none"
+	}
+	switch s := *score; {
+	case s >= 9:
+		return "critical"
+	case s >= 7:
+		return "high"
+	case s >= 4:
+		return "medium"
+	case s > 0:
+		return "low"
+	}
+	return "none"
+}
+
+// cvssRange returns the CVSS score range a score falls in.
+func cvssRange(score float64) string {
+	switch {
+	case score >= 9:
+		return "9.0-10.0"
+	case score >= 7:
+		return "7.0-8.9"
+	case score >= 4:
+		return "4.0-6.9"
+	}
+	return "0.0-3.9"
+}
+
+type vulnerabilitySummary struct {
+	total           int
+	affectedDevices int
+	// bySeverity holds the sorted CVE IDs of each severity.
+	bySeverity  map[string][]string
+	byCVSSRange map[string]int
+	oldest      time.Time
+	software    []vulnerabilitySoftwareSummaryModel
+	blueprints  []vulnerabilityBlueprintSummaryModel
+}
+
+// summarizeVulnerabilities aggregates the vulnerabilities by severity and CVSS
+// range, and their detections by software and blueprint. Detections of a CVE
+// missing from vulnerabilities are classified by their own severity and score.
+func summarizeVulnerabilities(vulnerabilities []client.Vulnerability, detections []client.VulnerabilityDetection) vulnerabilitySummary {
+	summary := vulnerabilitySummary{
+		bySeverity:  map[string][]string{},
+		byCVSSRange: map[string]int{"0.0-3.9": 0, "4.0-6.9": 0, "7.0-8.9": 0, "9.0-10.0": 0},
+	}
+	for _, severity := range vulnerabilitySeverities {
+		summary.bySeverity[severity] = []string{}
+	}
+
+	severities := map[string]string{}
+	addOldest := func(v *string) {
+		if v == nil {
+			return
+		}
+		if t, ok := parsePrismTime(*v); ok && (summary.oldest.IsZero() || t.Before(summary.oldest)) {
+			summary.oldest = t
+		}
+	}
+	add := func(cveID, severity string, score *float64) {
+		if _, ok := severities[cveID]; ok {
+			return
+		}
+		severities[cveID] = vulnerabilitySeverity(severity, score)
+		summary.bySeverity[severities[cveID]] = append(summary.bySeverity[severities[cveID]], cveID)
+		if score != nil {
+			summary.byCVSSRange[cvssRange(*score)]++
+		}
+	}
+
+	for _, v := range vulnerabilities {
+		// The vulnerabilities endpoint reports a missing score as zero.
+		var score *float64
+		if v.CVSSScore > 0 {
+			score = &v.CVSSScore
+		}
+		add(v.CVEID, v.Severity, score)
+		addOldest(&v.FirstDetectionDate)
+	}
+	for _, det := range detections {
+		severity := ""
+		if det.Severity != nil {
+			severity = *det.Severity
+		}
+		add(det.CVEID, severity, det.CVSSScore)
+		addOldest(det.FirstDetectionDate)
+	}
+	summary.total = len(severities)
+	for _, cves := range summary.bySeverity {
+		sort.Strings(cves)
+	}
+
+	type group struct {
+		cves    map[string]bool
+		devices map[string]bool
+	}
+	newGroup := func() *group { return &group{cves: map[string]bool{}, devices: map[string]bool{}} }
+	counts := func(g *group) (vulnerabilities, devices, critical, high types.Int64) {
+		var c, h int64
+		for cve := range g.cves {
+			switch severities[cve] {
+			case "critical":
+				c++
+			case "high":
+				h++
+			}
+		}
+		return types.Int64Value(int64(len(g.cves))), types.Int64Value(int64(len(g.devices))), types.Int64Value(c), types.Int64Value(h)
+	}
+
+	devices := map[string]bool{}
+	software := map[string]*group{}
+	blueprints := map[string]*group{}
+	blueprintNames := map[string]*string{}
+	for i, det := range detections {
+		device := fmt.Sprintf("detection:%d", i)
+		if det.DeviceID != nil {
+			device = *det.DeviceID
+		} else if det.DeviceSerialNumber != nil {
+			device = *det.DeviceSerialNumber
+		}
+		devices[device] = true
+
+		if det.Name != nil {
+			g, ok := software[*det.Name]
+			if !ok {
+				g = newGroup()
+				software[*det.Name] = g
+			}
+			g.cves[det.CVEID] = true
+			g.devices[device] = true
+		}
+
+		blueprintID := ""
+		if det.BlueprintID != nil {
+			blueprintID = *det.BlueprintID
+		}
+		g, ok := blueprints[blueprintID]
+		if !ok {
+			g = newGroup()
+			blueprints[blueprintID] = g
+		}
+		if blueprintNames[blueprintID] == nil {
+			blueprintNames[blueprintID] = det.BlueprintName
+		}
+		g.cves[det.CVEID] = true
+		g.devices[device] = true
+	}
+	summary.affectedDevices = len(devices)
+
+	summary.software = make([]vulnerabilitySoftwareSummaryModel, 0, len(software))
+	for name, g := range software {
+		model := vulnerabilitySoftwareSummaryModel{Name: types.StringValue(name)}
+		model.VulnerabilityCount, model.DeviceCount, model.CriticalCount, model.HighCount = counts(g)
+		summary.software = append(summary.software, model)
+	}
+	sort.Slice(summary.software, func(i, j int) bool {
+		a, b := summary.software[i], summary.software[j]
+		if a.DeviceCount.ValueInt64() != b.DeviceCount.ValueInt64() {
+			return a.DeviceCount.ValueInt64() > b.DeviceCount.ValueInt64()
+		}
+		if a.VulnerabilityCount.ValueInt64() != b.VulnerabilityCount.ValueInt64() {
+			return a.VulnerabilityCount.ValueInt64() > b.VulnerabilityCount.ValueInt64()
+		}
+		return a.Name.ValueString() < b.Name.ValueString()
+	})
+
+	summary.blueprints = make([]vulnerabilityBlueprintSummaryModel, 0, len(blueprints))
+	for id, g := range blueprints {
+		model := vulnerabilityBlueprintSummaryModel{
+			BlueprintID:   types.StringValue(id),
+			BlueprintName: types.StringPointerValue(blueprintNames[id]),
+		}
+		if id == "" {
+			model.BlueprintID = types.StringNull()
+		}
+		model.VulnerabilityCount, model.DeviceCount, model.CriticalCount, model.HighCount = counts(g)
+		summary.blueprints = append(summary.blueprints, model)
+	}
+	sort.Slice(summary.blueprints, func(i, j int) bool {
+		a, b := summary.blueprints[i], summary.blueprints[j]
+		if a.BlueprintName.ValueString() != b.BlueprintName.ValueString() {
+			return a.BlueprintName.ValueString() < b.BlueprintName.ValueString()
+		}
+		return a.BlueprintID.ValueString() < b.BlueprintID.ValueString()
+	})
+
+	return summary
+}
+
+// vulnerabilityExamples lists the first CVE IDs for a threshold error.
+func vulnerabilityExamples(cves []string) string {
+	if len(cves) <= vulnerabilityThresholdExampleLimit {
+		return strings.Join(cves, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(cves[:vulnerabilityThresholdExampleLimit], ", "), len(cves)-vulnerabilityThresholdExampleLimit)
+}
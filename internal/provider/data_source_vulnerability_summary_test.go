@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+)
+
+func TestSummarizeVulnerabilities(t *testing.T) {
+	str := func(s string) *string { return &s }
+	num := func(f float64) *float64 { return &f }
+
+	vulnerabilities := []client.Vulnerability{
+		{CVEID: "CVE-1", Severity: "Critical", CVSSScore: 9.8, FirstDetectionDate: "2024-03-01T00:00:00Z"},
+		{CVEID: "CVE-2", Severity: "High", CVSSScore: 7.5, FirstDetectionDate: "2024-01-15 08:00:00"},
+		{CVEID: "CVE-3", CVSSScore: 5.0},
+		{CVEID: "CVE-4", Severity: "Low"},
+	}
+	detections := []client.VulnerabilityDetection{
+		{CVEID: "CVE-1", DeviceID: str("d1"), Name: str("Google Chrome"), BlueprintID: str("b1"), BlueprintName: str("Engineering")},
+		{CVEID: "CVE-1", DeviceID: str("d2"), Name: str("Google Chrome"), BlueprintID: str("b2"), BlueprintName: str("Sales")},
+		{CVEID: "CVE-2", DeviceID: str("d1"), Name: str("Zoom"), BlueprintID: str("b1"), BlueprintName: str("Engineering")},
+		{CVEID: "CVE-5", DeviceID: str("d3"), Name: str("Zoom"), CVSSScore: num(9.1), FirstDetectionDate: str("2023-12-31T00:00:00Z")},
+	}
+
+	summary := summarizeVulnerabilities(vulnerabilities, detections)
+	if summary.total != 5 || summary.affectedDevices != 3 {
+		t.Errorf("Expected 5 vulnerabilities on 3 devices, got %d on %d", summary.total, summary.affectedDevices)
+	}
+	if got := summary.bySeverity["critical"]; len(got) != 2 || got[0] != "CVE-1" || got[1] != "CVE-5" {
+		t.Errorf("Expected CVE-1 and CVE-5 to be critical, got %v", got)
+	}
+	if got := summary.bySeverity["medium"]; len(got) != 1 || got[0] != "CVE-3" {
+		t.Errorf("Expected CVE-3 to be classified as medium by its score, got %v", got)
+	}
+	if got := summary.bySeverity["none"]; len(got) != 0 {
+		t.Errorf("Expected no vulnerabilities without a severity, got %v", got)
+	}
+	if summary.byCVSSRange["9.0-10.0"] != 2 || summary.byCVSSRange["7.0-8.9"] != 1 || summary.byCVSSRange["4.0-6.9"] != 1 || summary.byCVSSRange["0.0-3.9"] != 0 {
+		t.Errorf("Unexpected CVSS ranges: %v", summary.byCVSSRange)
+	}
+	if got := summary.oldest.Format("2006-01-02"); got != "2023-12-31" {
+		t.Errorf("Expected the oldest detection from the detections, got %s", got)
+	}
+
+	if len(summary.software) != 2 {
+		t.Fatalf("Expected 2 software entries, got %v", summary.software)
+	}
+	if zoom := summary.software[0]; zoom.Name.ValueString() != "Zoom" || zoom.VulnerabilityCount.ValueInt64() != 2 || zoom.CriticalCount.ValueInt64() != 1 || zoom.HighCount.ValueInt64() != 1 {
+		t.Errorf("Expected Zoom first with a critical and a high vulnerability, got %v", zoom)
+	}
+	if chrome := summary.software[1]; chrome.Name.ValueString() != "Google Chrome" || chrome.DeviceCount.ValueInt64() != 2 || chrome.VulnerabilityCount.ValueInt64() != 1 {
+		t.Errorf("Expected Chrome on 2 devices with fewer vulnerabilities second, got %v", chrome)
+	}
+
+	if len(summary.blueprints) != 3 {
+		t.Fatalf("Expected 3 blueprint groups, got %v", summary.blueprints)
+	}
+	if unassigned := summary.blueprints[0]; !unassigned.BlueprintID.IsNull() || unassigned.DeviceCount.ValueInt64() != 1 {
+		t.Errorf("Expected detections without a blueprint grouped first, got %v", unassigned)
+	}
+	if engineering := summary.blueprints[1]; engineering.BlueprintName.ValueString() != "Engineering" || engineering.VulnerabilityCount.ValueInt64() != 2 || engineering.DeviceCount.ValueInt64() != 1 {
+		t.Errorf("Unexpected Engineering group: %v", engineering)
+	}
+}
+
+func TestVulnerabilityExamples(t *testing.T) {
+	cves := make([]string, vulnerabilityThresholdExampleLimit+2)
+	for i := range cves {
+		cves[i] = "CVE"
+	}
+	if got := vulnerabilityExamples(cves); !strings.HasSuffix(got, "and 2 more") {
+		t.Errorf("Expected the remaining CVEs to be counted, got %s", got)
+	}
+}
@@ -3,10 +3,8 @@ package provider
 import (
 	"context"
 	"fmt"
-	"slices"
 
 	"github.com/MScottBlake/terraform-provider-iru/internal/client"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/list"
 	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -30,7 +28,49 @@ func (r *adeDeviceListResource) Metadata(ctx context.Context, req resource.Metad
 
 func (r *adeDeviceListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
 	resp.Schema = listschema.Schema{
-		MarkdownDescription: "Lists Iru ADE Device resources.",
+		MarkdownDescription: "Lists Iru ADE Device resources. The optional filters match those of the `iru_ade_devices` data source, so `terraform query` can bulk-import a subset of devices.",
+		Attributes: map[string]listschema.Attribute{
+			"blueprint_id": listschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Filter by blueprint ID.",
+			},
+			"user_id": listschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Filter by user ID.",
+			},
+			"dep_account": listschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Filter by DEP account.",
+			},
+			"device_family": listschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Filter by device family.",
+			},
+			"model": listschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Filter by model.",
+			},
+			"os": listschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Filter by OS.",
+			},
+			"profile_status": listschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Filter by profile status.",
+			},
+			"serial_number": listschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Filter by serial number.",
+			},
+			"serial_number_prefix": listschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Filter by the start of the serial number, ignoring case.",
+			},
+			"is_enrolled": listschema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Filter by whether the device is enrolled.",
+			},
+		},
 	}
 }
 
@@ -52,34 +92,16 @@ func (r *adeDeviceListResource) Configure(ctx context.Context, req resource.Conf
 }
 
 func (r *adeDeviceListResource) List(ctx context.Context, req list.ListRequest, resp *list.ListResultsStream) {
-	var allDevices []client.ADEDevice
-	page := 1
-	
-	for {
-		path := fmt.Sprintf("/api/v1/integrations/apple/ade/devices?page=%d", page)
-		
-		type adeDevicesResponse struct {
-			Results []client.ADEDevice `json:"results"`
-			Next    string             `json:"next"`
-		}
-		var listResp adeDevicesResponse
-		err := r.client.DoRequest(ctx, "GET", path, nil, &listResp)
-		if err != nil {
-			resp.Results = list.ListResultsStreamDiagnostics(diag.Diagnostics{
-				diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to list ADE devices, got error: %v", err)),
-			})
-			return
-		}
-
-		allDevices = append(allDevices, listResp.Results...)
-		if listResp.Next == "" || len(listResp.Results) == 0 {
-			break
-		}
-		page++
+	var filter adeDeviceFilterModel
+	diags := req.Config.Get(ctx, &filter)
+	if diags.HasError() {
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
+		return
 	}
 
-	results := make([]list.ListResult, 0, len(allDevices))
-	for _, device := range allDevices {
+	items := adeDevicePages(ctx, r.client, filter.params())
+
+	resp.Results = streamListResults(req, items, "Unable to list ADE devices", filter.matches, func(device client.ADEDevice) list.ListResult {
 		result := req.NewListResult(ctx)
 
 		identity := adeDeviceResourceIdentityModel{
@@ -89,16 +111,16 @@ func (r *adeDeviceListResource) List(ctx context.Context, req list.ListRequest,
 
 		if req.IncludeResource {
 			resourceModel := adeDeviceResourceModel{
-				ID:            types.StringValue(device.ID),
-				SerialNumber:  types.StringValue(device.SerialNumber),
-				Model:         types.StringValue(device.Model),
-				Description:   types.StringValue(device.Description),
-				AssetTag:      types.StringValue(device.AssetTag),
-				Color:         types.StringValue(device.Color),
-				BlueprintID:   types.StringValue(device.BlueprintID),
-				UserID:        types.StringValue(device.UserID),
-				DEPAccount:    types.StringValue(device.DEPAccount),
-				DeviceFamily:  types.StringValue(device.DeviceFamily),
+				ID:                  types.StringValue(device.ID),
+				SerialNumber:        types.StringValue(device.SerialNumber),
+				Model:               types.StringValue(device.Model),
+				Description:         types.StringValue(device.Description),
+				AssetTag:            types.StringValue(device.AssetTag),
+				Color:               types.StringValue(device.Color),
+				BlueprintID:         types.StringValue(device.BlueprintID),
+				UserID:              types.StringValue(device.UserID),
+				DEPAccount:          types.StringValue(device.DEPAccount),
+				DeviceFamily:        types.StringValue(device.DeviceFamily),
 				OS:                  types.StringValue(device.OS),
 				ProfileStatus:       types.StringValue(device.ProfileStatus),
 				IsEnrolled:          types.BoolValue(device.IsEnrolled),
@@ -108,8 +130,6 @@ func (r *adeDeviceListResource) List(ctx context.Context, req list.ListRequest,
 		}
 
 		result.DisplayName = fmt.Sprintf("%s (%s)", device.Model, device.SerialNumber)
-		results = append(results, result)
-	}
-
-	resp.Results = slices.Values(results)
+		return result
+	})
 }
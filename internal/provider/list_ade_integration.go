@@ -3,10 +3,8 @@ package provider
 import (
 	"context"
 	"fmt"
-	"slices"
 
 	"github.com/MScottBlake/terraform-provider-iru/internal/client"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/list"
 	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -28,7 +26,7 @@ func (r *adeIntegrationListResource) Metadata(ctx context.Context, req resource.
 	resp.TypeName = req.ProviderTypeName + "_ade_integration"
 }
 
-func (r *adeIntegrationListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {	resp.Schema = listschema.Schema{		MarkdownDescription: "Lists Iru Ade Integration resources.",	}
+func (r *adeIntegrationListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
 	resp.Schema = listschema.Schema{
 		MarkdownDescription: "Lists Iru ADE Integrations.",
 	}
@@ -52,19 +50,27 @@ func (r *adeIntegrationListResource) Configure(ctx context.Context, req resource
 }
 
 func (r *adeIntegrationListResource) List(ctx context.Context, req list.ListRequest, resp *list.ListResultsStream) {
-	var response struct {
-		Results []client.ADEIntegration `json:"results"`
-	}
-	err := r.client.DoRequest(ctx, "GET", "/api/v1/integrations/apple/ade/", nil, &response)
-	if err != nil {
-		resp.Results = list.ListResultsStreamDiagnostics(diag.Diagnostics{
-			diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to list ADE integrations, got error: %v", err)),
-		})
-		return
+	// The integrations endpoint is not paginated, so it is only requested once
+	// Terraform starts consuming results.
+	items := func(yield func(client.ADEIntegration, error) bool) {
+		var response struct {
+			Results []client.ADEIntegration `json:"results"`
+		}
+		err := r.client.DoRequest(ctx, "GET", "/api/v1/integrations/apple/ade/", nil, &response)
+		if err != nil {
+			yield(client.ADEIntegration{}, err)
+			return
+		}
+		for _, integration := range response.Results {
+			if !yield(integration, nil) {
+				return
+			}
+		}
 	}
 
-	results := make([]list.ListResult, 0, len(response.Results))
-	for _, integration := range response.Results {
+	include := func(client.ADEIntegration) bool { return true }
+
+	resp.Results = streamListResults(req, items, "Unable to list ADE integrations", include, func(integration client.ADEIntegration) list.ListResult {
 		result := req.NewListResult(ctx)
 
 		identity := adeIntegrationResourceIdentityModel{
@@ -99,8 +105,6 @@ func (r *adeIntegrationListResource) List(ctx context.Context, req list.ListRequ
 			display = integration.Defaults.Email
 		}
 		result.DisplayName = display
-		results = append(results, result)
-	}
-
-	resp.Results = slices.Values(results)
+		return result
+	})
 }
@@ -3,10 +3,9 @@ package provider
 import (
 	"context"
 	"fmt"
-	"slices"
+	"net/url"
 
 	"github.com/MScottBlake/terraform-provider-iru/internal/client"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/list"
 	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -28,7 +27,21 @@ func (r *blueprintListResource) Metadata(ctx context.Context, req resource.Metad
 	resp.TypeName = req.ProviderTypeName + "_blueprint"
 }
 
-func (r *blueprintListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {	resp.Schema = listschema.Schema{		MarkdownDescription: "Lists Iru Blueprint resources.",	}
+type blueprintListResourceModel struct {
+	Name         types.String `tfsdk:"name"`
+	NameRegex    types.String `tfsdk:"name_regex"`
+	CreatedAfter types.String `tfsdk:"created_after"`
+}
+
+func (r *blueprintListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		MarkdownDescription: "Lists Iru Blueprint resources.",
+		Attributes: map[string]listschema.Attribute{
+			"name":          listNameAttribute,
+			"name_regex":    listNameRegexAttribute,
+			"created_after": listCreatedAfterAttribute,
+		},
+	}
 }
 
 func (r *blueprintListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -49,19 +62,27 @@ func (r *blueprintListResource) Configure(ctx context.Context, req resource.Conf
 }
 
 func (r *blueprintListResource) List(ctx context.Context, req list.ListRequest, resp *list.ListResultsStream) {
-	var response struct {
-		Results []client.Blueprint `json:"results"`
-	}
-	err := r.client.DoRequest(ctx, "GET", "/api/v1/blueprints", nil, &response)
-	if err != nil {
-		resp.Results = list.ListResultsStreamDiagnostics(diag.Diagnostics{
-			diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to list blueprints, got error: %v", err)),
-		})
+	var config blueprintListResourceModel
+	diags := req.Config.Get(ctx, &config)
+	filters, filterDiags := newListFilters(config.Name, config.NameRegex, types.BoolNull(), types.StringNull(), config.CreatedAfter)
+	diags.Append(filterDiags...)
+	if diags.HasError() {
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
 		return
 	}
 
-	results := make([]list.ListResult, 0, len(response.Results))
-	for _, blueprint := range response.Results {
+	params := url.Values{}
+	if !config.Name.IsNull() {
+		params.Add("name", config.Name.ValueString())
+	}
+
+	items := resultsPages[client.Blueprint](ctx, r.client, "/api/v1/blueprints", params)
+
+	include := func(blueprint client.Blueprint) bool {
+		return filters.matchName(blueprint.Name) && filters.matchCreated(blueprint.CreatedAt)
+	}
+
+	resp.Results = streamListResults(req, items, "Unable to list blueprints", include, func(blueprint client.Blueprint) list.ListResult {
 		result := req.NewListResult(ctx)
 
 		identity := blueprintResourceIdentityModel{
@@ -82,8 +103,6 @@ func (r *blueprintListResource) List(ctx context.Context, req list.ListRequest,
 		}
 
 		result.DisplayName = blueprint.Name
-		results = append(results, result)
-	}
-
-	resp.Results = slices.Values(results)
+		return result
+	})
 }
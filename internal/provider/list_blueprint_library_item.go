@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ list.ListResource = &blueprintLibraryItemListResource{}
+var _ list.ListResourceWithConfigure = &blueprintLibraryItemListResource{}
+
+func NewBlueprintLibraryItemListResource() list.ListResource {
+	return &blueprintLibraryItemListResource{}
+}
+
+type blueprintLibraryItemListResource struct {
+	client *client.Client
+}
+
+type blueprintLibraryItemListResourceModel struct {
+	BlueprintID types.String `tfsdk:"blueprint_id"`
+	Name        types.String `tfsdk:"name"`
+	NameRegex   types.String `tfsdk:"name_regex"`
+}
+
+func (r *blueprintLibraryItemListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_blueprint_library_item"
+}
+
+func (r *blueprintLibraryItemListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		MarkdownDescription: "Lists the Library Items assigned to a Blueprint as Iru Blueprint Library Item resources.",
+		Attributes: map[string]listschema.Attribute{
+			"blueprint_id": listschema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The UUID of the blueprint whose library item assignments are listed.",
+			},
+			"name":       listNameAttribute,
+			"name_regex": listNameRegexAttribute,
+		},
+	}
+}
+
+func (r *blueprintLibraryItemListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *blueprintLibraryItemListResource) List(ctx context.Context, req list.ListRequest, resp *list.ListResultsStream) {
+	var config blueprintLibraryItemListResourceModel
+	diags := req.Config.Get(ctx, &config)
+	filters, filterDiags := newListFilters(config.Name, config.NameRegex, types.BoolNull(), types.StringNull(), types.StringNull())
+	diags.Append(filterDiags...)
+	if diags.HasError() {
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	bpID := config.BlueprintID.ValueString()
+	items := resultsPages[client.BlueprintLibraryItem](ctx, r.client, fmt.Sprintf("/api/v1/blueprints/%s/list-library-items", bpID), nil)
+
+	include := func(item client.BlueprintLibraryItem) bool {
+		return filters.matchName(item.Name)
+	}
+
+	resp.Results = streamListResults(req, items, "Unable to list blueprint library items", include, func(item client.BlueprintLibraryItem) list.ListResult {
+		result := req.NewListResult(ctx)
+
+		id := fmt.Sprintf("%s:%s", bpID, item.ID)
+		identity := blueprintLibraryItemResourceIdentityModel{
+			ID: types.StringValue(id),
+		}
+		result.Diagnostics.Append(result.Identity.Set(ctx, &identity)...)
+
+		if req.IncludeResource {
+			resourceModel := blueprintLibraryItemResourceModel{
+				ID:               types.StringValue(id),
+				BlueprintID:      types.StringValue(bpID),
+				LibraryItemID:    types.StringValue(item.ID),
+				AssignmentNodeID: types.StringNull(),
+			}
+			result.Diagnostics.Append(result.Resource.Set(ctx, &resourceModel)...)
+		}
+
+		result.DisplayName = item.Name
+		return result
+	})
+}
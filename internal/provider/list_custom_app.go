@@ -3,10 +3,8 @@ package provider
 import (
 	"context"
 	"fmt"
-	"slices"
 
 	"github.com/MScottBlake/terraform-provider-iru/internal/client"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/list"
 	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -28,7 +26,23 @@ func (r *customAppListResource) Metadata(ctx context.Context, req resource.Metad
 	resp.TypeName = req.ProviderTypeName + "_custom_app"
 }
 
-func (r *customAppListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {	resp.Schema = listschema.Schema{		MarkdownDescription: "Lists Iru Custom App resources.",	}
+type customAppListResourceModel struct {
+	Name         types.String `tfsdk:"name"`
+	NameRegex    types.String `tfsdk:"name_regex"`
+	Active       types.Bool   `tfsdk:"active"`
+	CreatedAfter types.String `tfsdk:"created_after"`
+}
+
+func (r *customAppListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		MarkdownDescription: "Lists Iru Custom App resources.",
+		Attributes: map[string]listschema.Attribute{
+			"name":          listNameAttribute,
+			"name_regex":    listNameRegexAttribute,
+			"active":        listActiveAttribute,
+			"created_after": listCreatedAfterAttribute,
+		},
+	}
 }
 
 func (r *customAppListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -49,19 +63,22 @@ func (r *customAppListResource) Configure(ctx context.Context, req resource.Conf
 }
 
 func (r *customAppListResource) List(ctx context.Context, req list.ListRequest, resp *list.ListResultsStream) {
-	var response struct {
-		Results []client.CustomApp `json:"results"`
-	}
-	err := r.client.DoRequest(ctx, "GET", "/api/v1/library/custom-apps", nil, &response)
-	if err != nil {
-		resp.Results = list.ListResultsStreamDiagnostics(diag.Diagnostics{
-			diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to list custom apps, got error: %v", err)),
-		})
+	var config customAppListResourceModel
+	diags := req.Config.Get(ctx, &config)
+	filters, filterDiags := newListFilters(config.Name, config.NameRegex, config.Active, types.StringNull(), config.CreatedAfter)
+	diags.Append(filterDiags...)
+	if diags.HasError() {
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
 		return
 	}
 
-	results := make([]list.ListResult, 0, len(response.Results))
-	for _, app := range response.Results {
+	items := resultsPages[client.CustomApp](ctx, r.client, "/api/v1/library/custom-apps", nil)
+
+	include := func(app client.CustomApp) bool {
+		return filters.matchName(app.Name) && filters.matchActive(app.Active) && filters.matchCreated(app.CreatedAt)
+	}
+
+	resp.Results = streamListResults(req, items, "Unable to list custom apps", include, func(app client.CustomApp) list.ListResult {
 		result := req.NewListResult(ctx)
 
 		identity := customAppResourceIdentityModel{
@@ -90,8 +107,6 @@ func (r *customAppListResource) List(ctx context.Context, req list.ListRequest,
 		}
 
 		result.DisplayName = app.Name
-		results = append(results, result)
-	}
-
-	resp.Results = slices.Values(results)
+		return result
+	})
 }
@@ -3,10 +3,9 @@ package provider
 import (
 	"context"
 	"fmt"
-	"slices"
+	"net/url"
 
 	"github.com/MScottBlake/terraform-provider-iru/internal/client"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/list"
 	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -28,7 +27,25 @@ func (r *customProfileListResource) Metadata(ctx context.Context, req resource.M
 	resp.TypeName = req.ProviderTypeName + "_custom_profile"
 }
 
-func (r *customProfileListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {	resp.Schema = listschema.Schema{		MarkdownDescription: "Lists Iru Custom Profile resources.",	}
+type customProfileListResourceModel struct {
+	Name         types.String `tfsdk:"name"`
+	NameRegex    types.String `tfsdk:"name_regex"`
+	Active       types.Bool   `tfsdk:"active"`
+	Platform     types.String `tfsdk:"platform"`
+	CreatedAfter types.String `tfsdk:"created_after"`
+}
+
+func (r *customProfileListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		MarkdownDescription: "Lists Iru Custom Profile resources.",
+		Attributes: map[string]listschema.Attribute{
+			"name":          listNameAttribute,
+			"name_regex":    listNameRegexAttribute,
+			"active":        listActiveAttribute,
+			"platform":      listLibraryPlatformAttribute,
+			"created_after": listCreatedAfterAttribute,
+		},
+	}
 }
 
 func (r *customProfileListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -49,19 +66,36 @@ func (r *customProfileListResource) Configure(ctx context.Context, req resource.
 }
 
 func (r *customProfileListResource) List(ctx context.Context, req list.ListRequest, resp *list.ListResultsStream) {
-	var response struct {
-		Results []client.CustomProfile `json:"results"`
-	}
-	err := r.client.DoRequest(ctx, "GET", "/api/v1/library/custom-profiles", nil, &response)
-	if err != nil {
-		resp.Results = list.ListResultsStreamDiagnostics(diag.Diagnostics{
-			diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to list custom profiles, got error: %v", err)),
-		})
+	var config customProfileListResourceModel
+	diags := req.Config.Get(ctx, &config)
+	filters, filterDiags := newListFilters(config.Name, config.NameRegex, config.Active, config.Platform, config.CreatedAfter)
+	diags.Append(filterDiags...)
+	if diags.HasError() {
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
 		return
 	}
 
-	results := make([]list.ListResult, 0, len(response.Results))
-	for _, profile := range response.Results {
+	params := url.Values{}
+	if !config.Name.IsNull() {
+		params.Add("name", config.Name.ValueString())
+	}
+
+	items := resultsPages[client.CustomProfile](ctx, r.client, "/api/v1/library/custom-profiles", params)
+
+	include := func(profile client.CustomProfile) bool {
+		return filters.matchName(profile.Name) &&
+			filters.matchActive(profile.Active) &&
+			filters.matchCreated(profile.CreatedAt) &&
+			filters.matchPlatform(map[string]bool{
+				"Mac":     profile.RunsOnMac,
+				"iPhone":  profile.RunsOnIPhone,
+				"iPad":    profile.RunsOnIPad,
+				"AppleTV": profile.RunsOnTV,
+				"Vision":  profile.RunsOnVision,
+			})
+	}
+
+	resp.Results = streamListResults(req, items, "Unable to list custom profiles", include, func(profile client.CustomProfile) list.ListResult {
 		result := req.NewListResult(ctx)
 
 		identity := customProfileResourceIdentityModel{
@@ -86,8 +120,6 @@ func (r *customProfileListResource) List(ctx context.Context, req list.ListReque
 		}
 
 		result.DisplayName = profile.Name
-		results = append(results, result)
-	}
-
-	resp.Results = slices.Values(results)
+		return result
+	})
 }
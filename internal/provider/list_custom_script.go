@@ -3,10 +3,9 @@ package provider
 import (
 	"context"
 	"fmt"
-	"slices"
+	"net/url"
 
 	"github.com/MScottBlake/terraform-provider-iru/internal/client"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/list"
 	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -28,7 +27,23 @@ func (r *customScriptListResource) Metadata(ctx context.Context, req resource.Me
 	resp.TypeName = req.ProviderTypeName + "_custom_script"
 }
 
-func (r *customScriptListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {	resp.Schema = listschema.Schema{		MarkdownDescription: "Lists Iru Custom Script resources.",	}
+type customScriptListResourceModel struct {
+	Name         types.String `tfsdk:"name"`
+	NameRegex    types.String `tfsdk:"name_regex"`
+	Active       types.Bool   `tfsdk:"active"`
+	CreatedAfter types.String `tfsdk:"created_after"`
+}
+
+func (r *customScriptListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		MarkdownDescription: "Lists Iru Custom Script resources.",
+		Attributes: map[string]listschema.Attribute{
+			"name":          listNameAttribute,
+			"name_regex":    listNameRegexAttribute,
+			"active":        listActiveAttribute,
+			"created_after": listCreatedAfterAttribute,
+		},
+	}
 }
 
 func (r *customScriptListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -49,19 +64,27 @@ func (r *customScriptListResource) Configure(ctx context.Context, req resource.C
 }
 
 func (r *customScriptListResource) List(ctx context.Context, req list.ListRequest, resp *list.ListResultsStream) {
-	var response struct {
-		Results []client.CustomScript `json:"results"`
-	}
-	err := r.client.DoRequest(ctx, "GET", "/api/v1/library/custom-scripts", nil, &response)
-	if err != nil {
-		resp.Results = list.ListResultsStreamDiagnostics(diag.Diagnostics{
-			diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to list custom scripts, got error: %v", err)),
-		})
+	var config customScriptListResourceModel
+	diags := req.Config.Get(ctx, &config)
+	filters, filterDiags := newListFilters(config.Name, config.NameRegex, config.Active, types.StringNull(), config.CreatedAfter)
+	diags.Append(filterDiags...)
+	if diags.HasError() {
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
 		return
 	}
 
-	results := make([]list.ListResult, 0, len(response.Results))
-	for _, script := range response.Results {
+	params := url.Values{}
+	if !config.Name.IsNull() {
+		params.Add("name", config.Name.ValueString())
+	}
+
+	items := resultsPages[client.CustomScript](ctx, r.client, "/api/v1/library/custom-scripts", params)
+
+	include := func(script client.CustomScript) bool {
+		return filters.matchName(script.Name) && filters.matchActive(script.Active) && filters.matchCreated(script.CreatedAt)
+	}
+
+	resp.Results = streamListResults(req, items, "Unable to list custom scripts", include, func(script client.CustomScript) list.ListResult {
 		result := req.NewListResult(ctx)
 
 		identity := customScriptResourceIdentityModel{
@@ -71,21 +94,21 @@ func (r *customScriptListResource) List(ctx context.Context, req list.ListReques
 
 		if req.IncludeResource {
 			resourceModel := customScriptResourceModel{
-				ID:                 types.StringValue(script.ID),
-				Name:               types.StringValue(script.Name),
-				Active:             types.BoolValue(script.Active),
-				ExecutionFrequency: types.StringValue(script.ExecutionFrequency),
-				Restart:            types.BoolValue(script.Restart),
-				Script:             types.StringValue(script.Script),
-				RemediationScript:  types.StringValue(script.RemediationScript),
-				ShowInSelfService:  types.BoolValue(script.ShowInSelfService),
+				ID:                     types.StringValue(script.ID),
+				Name:                   types.StringValue(script.Name),
+				Active:                 types.BoolValue(script.Active),
+				ExecutionFrequency:     types.StringValue(script.ExecutionFrequency),
+				Restart:                types.BoolValue(script.Restart),
+				Script:                 types.StringValue(script.Script),
+				RemediationScript:      types.StringValue(script.RemediationScript),
+				ShowInSelfService:      types.BoolValue(script.ShowInSelfService),
+				SelfServiceCategoryID:  types.StringValue(script.SelfServiceCategoryID),
+				SelfServiceRecommended: types.BoolValue(script.SelfServiceRecommended),
 			}
 			result.Diagnostics.Append(result.Resource.Set(ctx, &resourceModel)...)
 		}
 
 		result.DisplayName = script.Name
-		results = append(results, result)
-	}
-
-	resp.Results = slices.Values(results)
+		return result
+	})
 }
@@ -3,10 +3,10 @@ package provider
 import (
 	"context"
 	"fmt"
-	"slices"
+	"iter"
+	"net/url"
 
 	"github.com/MScottBlake/terraform-provider-iru/internal/client"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/list"
 	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -28,7 +28,29 @@ func (r *deviceListResource) Metadata(ctx context.Context, req resource.Metadata
 	resp.TypeName = req.ProviderTypeName + "_device"
 }
 
-func (r *deviceListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {	resp.Schema = listschema.Schema{		MarkdownDescription: "Lists Iru Device resources.",	}
+type deviceListResourceModel struct {
+	Name        types.String `tfsdk:"name"`
+	NameRegex   types.String `tfsdk:"name_regex"`
+	BlueprintID types.String `tfsdk:"blueprint_id"`
+	Platform    types.String `tfsdk:"platform"`
+}
+
+func (r *deviceListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		MarkdownDescription: "Lists Iru Device resources.",
+		Attributes: map[string]listschema.Attribute{
+			"name":       listNameAttribute,
+			"name_regex": listNameRegexAttribute,
+			"blueprint_id": listschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list devices assigned to this blueprint.",
+			},
+			"platform": listschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list devices of this platform, such as `Mac`, `iPhone`, `iPad` or `AppleTV`.",
+			},
+		},
+	}
 }
 
 func (r *deviceListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -49,17 +71,33 @@ func (r *deviceListResource) Configure(ctx context.Context, req resource.Configu
 }
 
 func (r *deviceListResource) List(ctx context.Context, req list.ListRequest, resp *list.ListResultsStream) {
-	var devices []client.Device
-	err := r.client.DoRequest(ctx, "GET", "/api/v1/devices", nil, &devices)
-	if err != nil {
-		resp.Results = list.ListResultsStreamDiagnostics(diag.Diagnostics{
-			diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to list devices, got error: %v", err)),
-		})
+	var config deviceListResourceModel
+	diags := req.Config.Get(ctx, &config)
+	filters, filterDiags := newListFilters(config.Name, config.NameRegex, types.BoolNull(), types.StringNull(), types.StringNull())
+	diags.Append(filterDiags...)
+	if diags.HasError() {
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
 		return
 	}
 
-	results := make([]list.ListResult, 0, len(devices))
-	for _, device := range devices {
+	params := url.Values{}
+	if !config.Name.IsNull() {
+		params.Add("device_name", config.Name.ValueString())
+	}
+	if !config.BlueprintID.IsNull() {
+		params.Add("blueprint_id", config.BlueprintID.ValueString())
+	}
+	if !config.Platform.IsNull() {
+		params.Add("platform", config.Platform.ValueString())
+	}
+
+	devices := devicePages(ctx, r.client, params)
+
+	include := func(device client.Device) bool {
+		return filters.matchName(device.DeviceName)
+	}
+
+	resp.Results = streamListResults(req, devices, "Unable to list devices", include, func(device client.Device) list.ListResult {
 		result := req.NewListResult(ctx)
 
 		// API returns device_id for list
@@ -85,8 +123,23 @@ func (r *deviceListResource) List(ctx context.Context, req list.ListRequest, res
 		}
 
 		result.DisplayName = fmt.Sprintf("%s (%s)", device.DeviceName, device.SerialNumber)
-		results = append(results, result)
-	}
+		return result
+	})
+}
+
+// devicePages iterates over the devices matching params. The devices endpoint
+// returns a bare array rather than a `results` wrapper.
+func devicePages(ctx context.Context, c *client.Client, params url.Values) iter.Seq2[client.Device, error] {
+	return offsetPages(func(limit, offset int) ([]client.Device, error) {
+		query := url.Values{}
+		for key, values := range params {
+			query[key] = values
+		}
+		query.Set("limit", fmt.Sprintf("%d", limit))
+		query.Set("offset", fmt.Sprintf("%d", offset))
 
-	resp.Results = slices.Values(results)
+		var page []client.Device
+		err := c.DoRequest(ctx, "GET", "/api/v1/devices?"+query.Encode(), nil, &page)
+		return page, err
+	})
 }
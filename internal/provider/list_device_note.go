@@ -0,0 +1,178 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ list.ListResource = &deviceNoteListResource{}
+var _ list.ListResourceWithConfigure = &deviceNoteListResource{}
+
+func NewDeviceNoteListResource() list.ListResource {
+	return &deviceNoteListResource{}
+}
+
+type deviceNoteListResource struct {
+	client *client.Client
+}
+
+type deviceNoteListResourceModel struct {
+	DeviceID    types.String `tfsdk:"device_id"`
+	DeviceName  types.String `tfsdk:"device_name"`
+	BlueprintID types.String `tfsdk:"blueprint_id"`
+	Platform    types.String `tfsdk:"platform"`
+	Author      types.String `tfsdk:"author"`
+}
+
+// deviceNoteListItem is a note together with the device it is attached to.
+type deviceNoteListItem struct {
+	deviceID string
+	note     client.DeviceNote
+}
+
+func (r *deviceNoteListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_note"
+}
+
+func (r *deviceNoteListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		MarkdownDescription: "Lists Iru Device Note resources, either of a single device or of every device matching the device filters.",
+		Attributes: map[string]listschema.Attribute{
+			"device_id": listschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list the notes of this device. When set, the other device filters are ignored.",
+			},
+			"device_name": listschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list the notes of devices with this name.",
+			},
+			"blueprint_id": listschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list the notes of devices assigned to this blueprint.",
+			},
+			"platform": listschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list the notes of devices of this platform, such as `Mac`, `iPhone`, `iPad` or `AppleTV`.",
+			},
+			"author": listschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list notes written by this author.",
+			},
+		},
+	}
+}
+
+func (r *deviceNoteListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *deviceNoteListResource) List(ctx context.Context, req list.ListRequest, resp *list.ListResultsStream) {
+	var config deviceNoteListResourceModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var deviceIDs iter.Seq2[string, error]
+	if !config.DeviceID.IsNull() {
+		deviceIDs = func(yield func(string, error) bool) {
+			yield(config.DeviceID.ValueString(), nil)
+		}
+	} else {
+		params := url.Values{}
+		if !config.DeviceName.IsNull() {
+			params.Add("device_name", config.DeviceName.ValueString())
+		}
+		if !config.BlueprintID.IsNull() {
+			params.Add("blueprint_id", config.BlueprintID.ValueString())
+		}
+		if !config.Platform.IsNull() {
+			params.Add("platform", config.Platform.ValueString())
+		}
+
+		deviceIDs = func(yield func(string, error) bool) {
+			for device, err := range devicePages(ctx, r.client, params) {
+				if !yield(device.ID, err) || err != nil {
+					return
+				}
+			}
+		}
+	}
+
+	include := func(item deviceNoteListItem) bool {
+		return config.Author.IsNull() || item.note.Author == config.Author.ValueString()
+	}
+
+	resp.Results = streamListResults(req, deviceNotes(ctx, r.client, deviceIDs), "Unable to list device notes", include, func(item deviceNoteListItem) list.ListResult {
+		result := req.NewListResult(ctx)
+
+		id := fmt.Sprintf("%s:%s", item.deviceID, item.note.ID)
+		identity := deviceNoteResourceIdentityModel{
+			ID: types.StringValue(id),
+		}
+		result.Diagnostics.Append(result.Identity.Set(ctx, &identity)...)
+
+		if req.IncludeResource {
+			resourceModel := deviceNoteResourceModel{
+				ID:        types.StringValue(id),
+				DeviceID:  types.StringValue(item.deviceID),
+				Content:   types.StringValue(item.note.Content),
+				Author:    types.StringValue(item.note.Author),
+				CreatedAt: types.StringValue(item.note.CreatedAt),
+				UpdatedAt: types.StringValue(item.note.UpdatedAt),
+			}
+			result.Diagnostics.Append(result.Resource.Set(ctx, &resourceModel)...)
+		}
+
+		result.DisplayName = fmt.Sprintf("%s (%s)", item.note.ID, item.deviceID)
+		return result
+	})
+}
+
+// deviceNotes iterates over the notes of the given devices, requesting the
+// notes of a device only once the notes of the previous one were consumed.
+func deviceNotes(ctx context.Context, c *client.Client, deviceIDs iter.Seq2[string, error]) iter.Seq2[deviceNoteListItem, error] {
+	return func(yield func(deviceNoteListItem, error) bool) {
+		for deviceID, err := range deviceIDs {
+			if err != nil {
+				yield(deviceNoteListItem{}, err)
+				return
+			}
+
+			var notes []client.DeviceNote
+			err := c.DoRequest(ctx, "GET", fmt.Sprintf("/api/v1/devices/%s/notes", deviceID), nil, &notes)
+			if err != nil {
+				yield(deviceNoteListItem{}, err)
+				return
+			}
+
+			for _, note := range notes {
+				if !yield(deviceNoteListItem{deviceID: deviceID, note: note}, nil) {
+					return
+				}
+			}
+		}
+	}
+}
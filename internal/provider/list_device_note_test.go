@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+)
+
+func TestDeviceNotesAcrossDevices(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		switch r.URL.Path {
+		case "/api/v1/devices":
+			if got := r.URL.Query().Get("blueprint_id"); got != "bp-1" {
+				t.Errorf("Expected blueprint_id=bp-1, got %q", got)
+			}
+			_, _ = w.Write([]byte(`[{"device_id": "d1"}, {"device_id": "d2"}, {"device_id": "d3"}]`))
+		case "/api/v1/devices/d1/notes":
+			_, _ = w.Write([]byte(`[]`))
+		case "/api/v1/devices/d2/notes":
+			_, _ = w.Write([]byte(`[{"note_id": "n1", "content": "first"}, {"note_id": "n2", "content": "second"}]`))
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := client.NewClient(server.URL, "test-token")
+	notes := deviceNotes(context.Background(), c, func(yield func(string, error) bool) {
+		for device, err := range devicePages(context.Background(), c, map[string][]string{"blueprint_id": {"bp-1"}}) {
+			if !yield(device.ID, err) || err != nil {
+				return
+			}
+		}
+	})
+
+	var ids []string
+	for item, err := range notes {
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		ids = append(ids, item.deviceID+":"+item.note.ID)
+		if len(ids) == 2 {
+			break
+		}
+	}
+
+	if len(ids) != 2 || ids[0] != "d2:n1" || ids[1] != "d2:n2" {
+		t.Errorf("Expected notes d2:n1 and d2:n2, got %v", ids)
+	}
+	if len(requested) != 3 {
+		t.Errorf("Expected the notes of d3 not to be requested, got requests %v", requested)
+	}
+}
@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// listPageSize is the page size list resources request from paginated
+// endpoints.
+const listPageSize = 300
+
+// listFilters holds the client-side filters of a list resource. Filters that
+// a list resource does not offer are left unset and match everything.
+type listFilters struct {
+	name         string
+	nameRegex    *regexp.Regexp
+	active       *bool
+	platform     string
+	createdAfter time.Time
+}
+
+// newListFilters validates and parses list resource filters. Pass null values
+// for filters the list resource does not offer.
+func newListFilters(name, nameRegex types.String, active types.Bool, platform, createdAfter types.String) (listFilters, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var f listFilters
+
+	f.name = name.ValueString()
+	f.platform = platform.ValueString()
+
+	if !nameRegex.IsNull() {
+		re, err := regexp.Compile(nameRegex.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("name_regex"), "Invalid Name Regex", fmt.Sprintf("Unable to compile %q, got error: %s", nameRegex.ValueString(), err))
+		}
+		f.nameRegex = re
+	}
+
+	if !active.IsNull() {
+		value := active.ValueBool()
+		f.active = &value
+	}
+
+	if !createdAfter.IsNull() {
+		t, err := time.Parse(time.RFC3339, createdAfter.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("created_after"), "Invalid Created After", fmt.Sprintf("`created_after` must be an RFC 3339 timestamp such as `2025-01-01T00:00:00Z`, got %q.", createdAfter.ValueString()))
+		}
+		f.createdAfter = t
+	}
+
+	return f, diags
+}
+
+func (f listFilters) matchName(name string) bool {
+	if f.name != "" && name != f.name {
+		return false
+	}
+	if f.nameRegex != nil && !f.nameRegex.MatchString(name) {
+		return false
+	}
+	return true
+}
+
+func (f listFilters) matchActive(active bool) bool {
+	return f.active == nil || *f.active == active
+}
+
+// matchPlatform reports whether an item that runs on the given platforms
+// matches the platform filter. Platform names are compared ignoring case.
+func (f listFilters) matchPlatform(platforms map[string]bool) bool {
+	if f.platform == "" {
+		return true
+	}
+	for platform, supported := range platforms {
+		if supported && strings.EqualFold(platform, f.platform) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchCreated reports whether createdAt is after the created_after filter.
+// Items without a parseable creation time do not match when the filter is
+// set.
+func (f listFilters) matchCreated(createdAt string) bool {
+	if f.createdAfter.IsZero() {
+		return true
+	}
+	t, err := time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		return false
+	}
+	return t.After(f.createdAfter)
+}
+
+// Config schema attributes shared by the list resources.
+var (
+	listNameAttribute = listschema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Only list items with exactly this name.",
+	}
+	listNameRegexAttribute = listschema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Only list items whose name matches this regular expression.",
+	}
+	listActiveAttribute = listschema.BoolAttribute{
+		Optional:            true,
+		MarkdownDescription: "Only list items that are active (`true`) or inactive (`false`).",
+	}
+	listCreatedAfterAttribute = listschema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Only list items created after this RFC 3339 timestamp, such as `2025-01-01T00:00:00Z`.",
+	}
+	listLibraryPlatformAttribute = listschema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Only list items that run on this platform: `Mac`, `iPhone`, `iPad`, `AppleTV` or `Vision`.",
+	}
+)
+
+// offsetPages iterates over every item of an endpoint paginated with limit
+// and offset. The next page is only requested once the items of the previous
+// page have been consumed. fetch returns one page of at most limit items.
+func offsetPages[T any](fetch func(limit, offset int) ([]T, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		offset := 0
+		for {
+			page, err := fetch(listPageSize, offset)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range page {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if len(page) < listPageSize {
+				return
+			}
+			offset += len(page)
+		}
+	}
+}
+
+// resultsPages iterates over an endpoint paginated with limit and offset
+// whose responses wrap the items in a `results` field.
+func resultsPages[T any](ctx context.Context, c *client.Client, endpoint string, params url.Values) iter.Seq2[T, error] {
+	return offsetPages(func(limit, offset int) ([]T, error) {
+		query := url.Values{}
+		for key, values := range params {
+			query[key] = values
+		}
+		query.Set("limit", fmt.Sprintf("%d", limit))
+		query.Set("offset", fmt.Sprintf("%d", offset))
+
+		var response struct {
+			Results []T `json:"results"`
+		}
+		err := c.DoRequest(ctx, "GET", endpoint+"?"+query.Encode(), nil, &response)
+		return response.Results, err
+	})
+}
+
+// streamListResults converts items into list results as Terraform consumes
+// them. Items for which include returns false are skipped, and the stream
+// ends after req.Limit results. An error while fetching items ends the stream
+// with an error diagnostic starting with summary.
+func streamListResults[T any](req list.ListRequest, items iter.Seq2[T, error], summary string, include func(T) bool, build func(T) list.ListResult) iter.Seq[list.ListResult] {
+	return func(push func(list.ListResult) bool) {
+		var count int64
+		for item, err := range items {
+			if err != nil {
+				push(list.ListResult{
+					Diagnostics: diag.Diagnostics{
+						diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("%s, got error: %v", summary, err)),
+					},
+				})
+				return
+			}
+			if !include(item) {
+				continue
+			}
+			if !push(build(item)) {
+				return
+			}
+			count++
+			if req.Limit > 0 && count >= req.Limit {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestOffsetPagesFetchesLazily(t *testing.T) {
+	var offsets []int
+	items := offsetPages(func(limit, offset int) ([]int, error) {
+		offsets = append(offsets, offset)
+		if offset >= 2*limit {
+			return make([]int, 10), nil
+		}
+		return make([]int, limit), nil
+	})
+
+	count := 0
+	for _, err := range items {
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		count++
+		if count == listPageSize {
+			break
+		}
+	}
+	if len(offsets) != 1 {
+		t.Fatalf("expected a single page request after consuming one page, got %v", offsets)
+	}
+
+	offsets = nil
+	count = 0
+	for range items {
+		count++
+	}
+	if count != 2*listPageSize+10 {
+		t.Errorf("expected %d items, got %d", 2*listPageSize+10, count)
+	}
+	if len(offsets) != 3 || offsets[2] != 2*listPageSize {
+		t.Errorf("unexpected page offsets %v", offsets)
+	}
+}
+
+func TestStreamListResults(t *testing.T) {
+	items := func(yield func(int, error) bool) {
+		for i := 1; i <= 5; i++ {
+			if !yield(i, nil) {
+				return
+			}
+		}
+		yield(0, errors.New("boom"))
+	}
+	even := func(i int) bool { return i%2 == 0 }
+	build := func(i int) list.ListResult { return list.ListResult{DisplayName: string(rune('0' + i))} }
+
+	var names []string
+	var errs int
+	for result := range streamListResults(list.ListRequest{}, items, "Unable to list numbers", even, build) {
+		if result.Diagnostics.HasError() {
+			errs++
+			continue
+		}
+		names = append(names, result.DisplayName)
+	}
+	if len(names) != 2 || names[0] != "2" || names[1] != "4" {
+		t.Errorf("unexpected results %v", names)
+	}
+	if errs != 1 {
+		t.Errorf("expected the fetch error to end the stream with one diagnostic, got %d", errs)
+	}
+
+	names = nil
+	for result := range streamListResults(list.ListRequest{Limit: 1}, items, "Unable to list numbers", even, build) {
+		names = append(names, result.DisplayName)
+	}
+	if len(names) != 1 || names[0] != "2" {
+		t.Errorf("expected the limit to stop the stream after one result, got %v", names)
+	}
+}
+
+func TestListFilters(t *testing.T) {
+	filters, diags := newListFilters(types.StringNull(), types.StringValue("^Prod"), types.BoolValue(true), types.StringValue("ipad"), types.StringValue("2025-01-01T00:00:00Z"))
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if !filters.matchName("Production") || filters.matchName("Staging") {
+		t.Error("name_regex filter did not match as expected")
+	}
+	if !filters.matchActive(true) || filters.matchActive(false) {
+		t.Error("active filter did not match as expected")
+	}
+	if !filters.matchPlatform(map[string]bool{"iPad": true}) || filters.matchPlatform(map[string]bool{"iPad": false, "Mac": true}) {
+		t.Error("platform filter did not match as expected")
+	}
+	if !filters.matchCreated("2025-06-01T12:00:00Z") || filters.matchCreated("2024-06-01T12:00:00Z") || filters.matchCreated("") {
+		t.Error("created_after filter did not match as expected")
+	}
+
+	_, diags = newListFilters(types.StringNull(), types.StringValue("("), types.BoolNull(), types.StringNull(), types.StringValue("yesterday"))
+	if diags.ErrorsCount() != 2 {
+		t.Errorf("expected errors for name_regex and created_after, got %v", diags)
+	}
+}
@@ -3,10 +3,8 @@ package provider
 import (
 	"context"
 	"fmt"
-	"slices"
 
 	"github.com/MScottBlake/terraform-provider-iru/internal/client"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/list"
 	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -28,7 +26,25 @@ func (r *inHouseAppListResource) Metadata(ctx context.Context, req resource.Meta
 	resp.TypeName = req.ProviderTypeName + "_in_house_app"
 }
 
-func (r *inHouseAppListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {	resp.Schema = listschema.Schema{		MarkdownDescription: "Lists Iru In House App resources.",	}
+type inHouseAppListResourceModel struct {
+	Name         types.String `tfsdk:"name"`
+	NameRegex    types.String `tfsdk:"name_regex"`
+	Active       types.Bool   `tfsdk:"active"`
+	Platform     types.String `tfsdk:"platform"`
+	CreatedAfter types.String `tfsdk:"created_after"`
+}
+
+func (r *inHouseAppListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		MarkdownDescription: "Lists Iru In House App resources.",
+		Attributes: map[string]listschema.Attribute{
+			"name":          listNameAttribute,
+			"name_regex":    listNameRegexAttribute,
+			"active":        listActiveAttribute,
+			"platform":      listLibraryPlatformAttribute,
+			"created_after": listCreatedAfterAttribute,
+		},
+	}
 }
 
 func (r *inHouseAppListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -49,19 +65,29 @@ func (r *inHouseAppListResource) Configure(ctx context.Context, req resource.Con
 }
 
 func (r *inHouseAppListResource) List(ctx context.Context, req list.ListRequest, resp *list.ListResultsStream) {
-	var response struct {
-		Results []client.InHouseApp `json:"results"`
-	}
-	err := r.client.DoRequest(ctx, "GET", "/api/v1/library/ipa-apps", nil, &response)
-	if err != nil {
-		resp.Results = list.ListResultsStreamDiagnostics(diag.Diagnostics{
-			diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to list in-house apps, got error: %v", err)),
-		})
+	var config inHouseAppListResourceModel
+	diags := req.Config.Get(ctx, &config)
+	filters, filterDiags := newListFilters(config.Name, config.NameRegex, config.Active, config.Platform, config.CreatedAfter)
+	diags.Append(filterDiags...)
+	if diags.HasError() {
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
 		return
 	}
 
-	results := make([]list.ListResult, 0, len(response.Results))
-	for _, app := range response.Results {
+	items := resultsPages[client.InHouseApp](ctx, r.client, "/api/v1/library/ipa-apps", nil)
+
+	include := func(app client.InHouseApp) bool {
+		return filters.matchName(app.Name) &&
+			filters.matchActive(app.Active) &&
+			filters.matchCreated(app.CreatedAt) &&
+			filters.matchPlatform(map[string]bool{
+				"iPhone":  app.RunsOnIPhone,
+				"iPad":    app.RunsOnIPad,
+				"AppleTV": app.RunsOnTV,
+			})
+	}
+
+	resp.Results = streamListResults(req, items, "Unable to list in-house apps", include, func(app client.InHouseApp) list.ListResult {
 		result := req.NewListResult(ctx)
 
 		identity := inHouseAppResourceIdentityModel{
@@ -83,8 +109,6 @@ func (r *inHouseAppListResource) List(ctx context.Context, req list.ListRequest,
 		}
 
 		result.DisplayName = app.Name
-		results = append(results, result)
-	}
-
-	resp.Results = slices.Values(results)
+		return result
+	})
 }
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ list.ListResource = &selfServiceCategoryListResource{}
+var _ list.ListResourceWithConfigure = &selfServiceCategoryListResource{}
+
+func NewSelfServiceCategoryListResource() list.ListResource {
+	return &selfServiceCategoryListResource{}
+}
+
+type selfServiceCategoryListResource struct {
+	client *client.Client
+}
+
+type selfServiceCategoryListResourceModel struct {
+	Name      types.String `tfsdk:"name"`
+	NameRegex types.String `tfsdk:"name_regex"`
+}
+
+func (r *selfServiceCategoryListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_self_service_category"
+}
+
+func (r *selfServiceCategoryListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		MarkdownDescription: "Lists Iru Self Service Category resources.",
+		Attributes: map[string]listschema.Attribute{
+			"name":       listNameAttribute,
+			"name_regex": listNameRegexAttribute,
+		},
+	}
+}
+
+func (r *selfServiceCategoryListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *selfServiceCategoryListResource) List(ctx context.Context, req list.ListRequest, resp *list.ListResultsStream) {
+	var config selfServiceCategoryListResourceModel
+	diags := req.Config.Get(ctx, &config)
+	filters, filterDiags := newListFilters(config.Name, config.NameRegex, types.BoolNull(), types.StringNull(), types.StringNull())
+	diags.Append(filterDiags...)
+	if diags.HasError() {
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	// The categories endpoint is not paginated, so it is only requested once
+	// Terraform starts consuming results.
+	items := func(yield func(client.SelfServiceCategory, error) bool) {
+		var categories []client.SelfServiceCategory
+		err := r.client.DoRequest(ctx, "GET", "/api/v1/library/self-service/categories", nil, &categories)
+		if err != nil {
+			yield(client.SelfServiceCategory{}, err)
+			return
+		}
+		for _, category := range categories {
+			if !yield(category, nil) {
+				return
+			}
+		}
+	}
+
+	include := func(category client.SelfServiceCategory) bool {
+		return filters.matchName(category.Name)
+	}
+
+	resp.Results = streamListResults(req, items, "Unable to list self service categories", include, func(category client.SelfServiceCategory) list.ListResult {
+		result := req.NewListResult(ctx)
+
+		identity := selfServiceCategoryResourceIdentityModel{
+			ID: types.StringValue(category.ID),
+		}
+		result.Diagnostics.Append(result.Identity.Set(ctx, &identity)...)
+
+		if req.IncludeResource {
+			resourceModel := selfServiceCategoryResourceModel{
+				ID:   types.StringValue(category.ID),
+				Name: types.StringValue(category.Name),
+			}
+			result.Diagnostics.Append(result.Resource.Set(ctx, &resourceModel)...)
+		}
+
+		result.DisplayName = category.Name
+		return result
+	})
+}
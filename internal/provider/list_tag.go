@@ -3,10 +3,9 @@ package provider
 import (
 	"context"
 	"fmt"
-	"slices"
+	"net/url"
 
 	"github.com/MScottBlake/terraform-provider-iru/internal/client"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/list"
 	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -28,7 +27,19 @@ func (r *tagListResource) Metadata(ctx context.Context, req resource.MetadataReq
 	resp.TypeName = req.ProviderTypeName + "_tag"
 }
 
-func (r *tagListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {	resp.Schema = listschema.Schema{		MarkdownDescription: "Lists Iru Tag resources.",	}
+type tagListResourceModel struct {
+	Name      types.String `tfsdk:"name"`
+	NameRegex types.String `tfsdk:"name_regex"`
+}
+
+func (r *tagListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		MarkdownDescription: "Lists Iru Tag resources.",
+		Attributes: map[string]listschema.Attribute{
+			"name":       listNameAttribute,
+			"name_regex": listNameRegexAttribute,
+		},
+	}
 }
 
 func (r *tagListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -49,19 +60,27 @@ func (r *tagListResource) Configure(ctx context.Context, req resource.ConfigureR
 }
 
 func (r *tagListResource) List(ctx context.Context, req list.ListRequest, resp *list.ListResultsStream) {
-	var response struct {
-		Results []client.Tag `json:"results"`
-	}
-	err := r.client.DoRequest(ctx, "GET", "/api/v1/tags", nil, &response)
-	if err != nil {
-		resp.Results = list.ListResultsStreamDiagnostics(diag.Diagnostics{
-			diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to list tags, got error: %v", err)),
-		})
+	var config tagListResourceModel
+	diags := req.Config.Get(ctx, &config)
+	filters, filterDiags := newListFilters(config.Name, config.NameRegex, types.BoolNull(), types.StringNull(), types.StringNull())
+	diags.Append(filterDiags...)
+	if diags.HasError() {
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
 		return
 	}
 
-	results := make([]list.ListResult, 0, len(response.Results))
-	for _, tag := range response.Results {
+	params := url.Values{}
+	if !config.Name.IsNull() {
+		params.Add("name", config.Name.ValueString())
+	}
+
+	items := resultsPages[client.Tag](ctx, r.client, "/api/v1/tags", params)
+
+	include := func(tag client.Tag) bool {
+		return filters.matchName(tag.Name)
+	}
+
+	resp.Results = streamListResults(req, items, "Unable to list tags", include, func(tag client.Tag) list.ListResult {
 		result := req.NewListResult(ctx)
 
 		identity := tagResourceIdentityModel{
@@ -78,8 +97,6 @@ func (r *tagListResource) List(ctx context.Context, req list.ListRequest, resp *
 		}
 
 		result.DisplayName = tag.Name
-		results = append(results, result)
-	}
-
-	resp.Results = slices.Values(results)
+		return result
+	})
 }
@@ -3,18 +3,20 @@ package provider
 import (
 	"context"
 	"fmt"
-	"slices"
+	"net/url"
 
 	"github.com/MScottBlake/terraform-provider-iru/internal/client"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/list"
 	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 var _ list.ListResource = &userListResource{}
 var _ list.ListResourceWithConfigure = &userListResource{}
+var _ list.ListResourceWithRawV6Schemas = &userListResource{}
 
 func NewUserListResource() list.ListResource {
 	return &userListResource{}
@@ -25,8 +27,10 @@ type userListResource struct {
 }
 
 type userResourceModel struct {
-	ID   types.String `tfsdk:"id"`
-	Name types.String `tfsdk:"name"`
+	ID         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	Email      types.String `tfsdk:"email"`
+	IsArchived types.Bool   `tfsdk:"is_archived"`
 }
 
 type userResourceIdentityModel struct {
@@ -37,7 +41,55 @@ func (r *userListResource) Metadata(ctx context.Context, req resource.MetadataRe
 	resp.TypeName = req.ProviderTypeName + "_user"
 }
 
-func (r *userListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {	resp.Schema = listschema.Schema{		MarkdownDescription: "Lists Iru User resources.",	}
+type userListResourceModel struct {
+	Name      types.String `tfsdk:"name"`
+	NameRegex types.String `tfsdk:"name_regex"`
+	Email     types.String `tfsdk:"email"`
+	Active    types.Bool   `tfsdk:"active"`
+}
+
+func (r *userListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		MarkdownDescription: "Lists Iru Users. Users are synchronized from directory integrations and cannot be managed by Terraform, so the results are for discovery only.",
+		Attributes: map[string]listschema.Attribute{
+			"name":       listNameAttribute,
+			"name_regex": listNameRegexAttribute,
+			"email": listschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list users with this email address.",
+			},
+			"active": listschema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list users that are not archived (`true`) or that are archived (`false`).",
+			},
+		},
+	}
+}
+
+// RawV6Schemas describes the listed users. Users are synchronized from the
+// directory integrations, so there is no managed iru_user resource to take the
+// schemas from.
+func (r *userListResource) RawV6Schemas(ctx context.Context, req list.RawV6SchemaRequest, resp *list.RawV6SchemaResponse) {
+	resp.ProtoV6IdentitySchema = &tfprotov6.ResourceIdentitySchema{
+		IdentityAttributes: []*tfprotov6.ResourceIdentitySchemaAttribute{
+			{
+				Name:              "id",
+				Type:              tftypes.String,
+				RequiredForImport: true,
+				Description:       "The unique identifier for the User.",
+			},
+		},
+	}
+	resp.ProtoV6Schema = &tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{Name: "id", Type: tftypes.String, Computed: true, Description: "The unique identifier for the User."},
+				{Name: "name", Type: tftypes.String, Computed: true, Description: "The name of the User."},
+				{Name: "email", Type: tftypes.String, Computed: true, Description: "The email address of the User."},
+				{Name: "is_archived", Type: tftypes.Bool, Computed: true, Description: "Whether the User is archived."},
+			},
+		},
+	}
 }
 
 func (r *userListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -58,19 +110,30 @@ func (r *userListResource) Configure(ctx context.Context, req resource.Configure
 }
 
 func (r *userListResource) List(ctx context.Context, req list.ListRequest, resp *list.ListResultsStream) {
-	var response struct {
-		Results []client.User `json:"results"`
-	}
-	err := r.client.DoRequest(ctx, "GET", "/api/v1/users", nil, &response)
-	if err != nil {
-		resp.Results = list.ListResultsStreamDiagnostics(diag.Diagnostics{
-			diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to list users, got error: %v", err)),
-		})
+	var config userListResourceModel
+	diags := req.Config.Get(ctx, &config)
+	filters, filterDiags := newListFilters(config.Name, config.NameRegex, config.Active, types.StringNull(), types.StringNull())
+	diags.Append(filterDiags...)
+	if diags.HasError() {
+		resp.Results = list.ListResultsStreamDiagnostics(diags)
 		return
 	}
 
-	results := make([]list.ListResult, 0, len(response.Results))
-	for _, user := range response.Results {
+	params := url.Values{}
+	if !config.Name.IsNull() {
+		params.Add("name", config.Name.ValueString())
+	}
+	if !config.Email.IsNull() {
+		params.Add("email", config.Email.ValueString())
+	}
+
+	items := resultsPages[client.User](ctx, r.client, "/api/v1/users", params)
+
+	include := func(user client.User) bool {
+		return filters.matchName(user.Name) && filters.matchActive(!user.IsArchived)
+	}
+
+	resp.Results = streamListResults(req, items, "Unable to list users", include, func(user client.User) list.ListResult {
 		result := req.NewListResult(ctx)
 
 		identity := userResourceIdentityModel{
@@ -80,15 +143,15 @@ func (r *userListResource) List(ctx context.Context, req list.ListRequest, resp
 
 		if req.IncludeResource {
 			resourceModel := userResourceModel{
-				ID:   types.StringValue(user.ID),
-				Name: types.StringValue(user.Name),
+				ID:         types.StringValue(user.ID),
+				Name:       types.StringValue(user.Name),
+				Email:      types.StringValue(user.Email),
+				IsArchived: types.BoolValue(user.IsArchived),
 			}
 			result.Diagnostics.Append(result.Resource.Set(ctx, &resourceModel)...)
 		}
 
 		result.DisplayName = user.Name
-		results = append(results, result)
-	}
-
-	resp.Results = slices.Values(results)
+		return result
+	})
 }
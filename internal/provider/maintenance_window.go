@@ -0,0 +1,209 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// maintenanceWindowModel describes a single entry of the provider
+// `maintenance_windows` attribute.
+type maintenanceWindowModel struct {
+	Days     types.List   `tfsdk:"days"`
+	Start    types.String `tfsdk:"start"`
+	End      types.String `tfsdk:"end"`
+	TimeZone types.String `tfsdk:"time_zone"`
+}
+
+// maintenanceWindow is a parsed weekly window. start and end are minutes after
+// midnight in location. When end is not after start the window ends on the
+// following day.
+type maintenanceWindow struct {
+	days     map[time.Weekday]bool
+	start    int
+	end      int
+	location *time.Location
+}
+
+// maintenanceSchedule gates disruptive actions on the configured maintenance
+// windows. now and after default to the system clock and can be replaced in
+// tests.
+type maintenanceSchedule struct {
+	windows []maintenanceWindow
+	wait    bool
+	now     func() time.Time
+	after   func(time.Duration) <-chan time.Time
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+func newMaintenanceSchedule(windows []maintenanceWindow, wait bool) *maintenanceSchedule {
+	return &maintenanceSchedule{
+		windows: windows,
+		wait:    wait,
+		now:     time.Now,
+		after:   time.After,
+	}
+}
+
+// parseMaintenanceWindow converts the provider configuration of a window into
+// a maintenanceWindow.
+func parseMaintenanceWindow(ctx context.Context, m maintenanceWindowModel) (maintenanceWindow, error) {
+	var days []string
+	if diags := m.Days.ElementsAs(ctx, &days, false); diags.HasError() {
+		return maintenanceWindow{}, fmt.Errorf("invalid days")
+	}
+	if len(days) == 0 {
+		return maintenanceWindow{}, fmt.Errorf("at least one day is required")
+	}
+
+	w := maintenanceWindow{days: map[time.Weekday]bool{}}
+	for _, day := range days {
+		weekday, err := parseWeekday(day)
+		if err != nil {
+			return maintenanceWindow{}, err
+		}
+		w.days[weekday] = true
+	}
+
+	var err error
+	if w.start, err = parseClock(m.Start.ValueString()); err != nil {
+		return maintenanceWindow{}, fmt.Errorf("invalid start: %w", err)
+	}
+	if w.end, err = parseClock(m.End.ValueString()); err != nil {
+		return maintenanceWindow{}, fmt.Errorf("invalid end: %w", err)
+	}
+
+	w.location = time.UTC
+	if !m.TimeZone.IsNull() && m.TimeZone.ValueString() != "" {
+		if w.location, err = time.LoadLocation(m.TimeZone.ValueString()); err != nil {
+			return maintenanceWindow{}, fmt.Errorf("invalid time_zone: %w", err)
+		}
+	}
+
+	return w, nil
+}
+
+func parseWeekday(day string) (time.Weekday, error) {
+	day = strings.ToLower(strings.TrimSpace(day))
+	for name, weekday := range weekdays {
+		if day == name || (len(day) == 3 && strings.HasPrefix(name, day)) {
+			return weekday, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown day %q", day)
+}
+
+// parseClock parses a 24-hour HH:MM time into minutes after midnight.
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("expected HH:MM, got %q", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// contains reports whether t falls inside the window.
+func (w maintenanceWindow) contains(t time.Time) bool {
+	local := t.In(w.location)
+	minutes := local.Hour()*60 + local.Minute()
+	today := local.Weekday()
+	yesterday := (today + 6) % 7
+
+	if w.start < w.end {
+		return w.days[today] && minutes >= w.start && minutes < w.end
+	}
+	return (w.days[today] && minutes >= w.start) || (w.days[yesterday] && minutes < w.end)
+}
+
+// nextStart returns the first time after t at which the window opens.
+func (w maintenanceWindow) nextStart(t time.Time) time.Time {
+	local := t.In(w.location)
+	for i := 0; i <= 7; i++ {
+		day := local.AddDate(0, 0, i)
+		if !w.days[day.Weekday()] {
+			continue
+		}
+		start := time.Date(day.Year(), day.Month(), day.Day(), w.start/60, w.start%60, 0, 0, w.location)
+		if start.After(t) {
+			return start
+		}
+	}
+	return time.Time{}
+}
+
+// open reports whether any window contains t.
+func (s *maintenanceSchedule) open(t time.Time) bool {
+	for _, w := range s.windows {
+		if w.contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// nextOpen returns the earliest time after t at which a window opens.
+func (s *maintenanceSchedule) nextOpen(t time.Time) time.Time {
+	var next time.Time
+	for _, w := range s.windows {
+		start := w.nextStart(t)
+		if !start.IsZero() && (next.IsZero() || start.Before(next)) {
+			next = start
+		}
+	}
+	return next
+}
+
+// checkMaintenanceWindow returns once a maintenance window is open. Outside a
+// window it either fails or waits for the next window to open, depending on
+// the provider configuration.
+func checkMaintenanceWindow(ctx context.Context, schedule *maintenanceSchedule, respect types.Bool, resp *action.InvokeResponse) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !respect.ValueBool() {
+		return diags
+	}
+
+	if schedule == nil || len(schedule.windows) == 0 {
+		diags.AddError("Maintenance Window Not Configured", "`respect_maintenance_window` is set, but the provider has no `maintenance_windows` configured.")
+		return diags
+	}
+
+	now := schedule.now()
+	if schedule.open(now) {
+		return diags
+	}
+
+	next := schedule.nextOpen(now)
+	if !schedule.wait {
+		diags.AddError("Outside Maintenance Window", fmt.Sprintf("The action may only run inside a maintenance window. The next window opens at %s.", next.Format(time.RFC3339)))
+		return diags
+	}
+
+	if resp != nil && resp.SendProgress != nil {
+		resp.SendProgress(action.InvokeProgressEvent{
+			Message: fmt.Sprintf("Waiting for the maintenance window opening at %s", next.Format(time.RFC3339)),
+		})
+	}
+
+	select {
+	case <-ctx.Done():
+		diags.AddError("Maintenance Window Wait Cancelled", fmt.Sprintf("Stopped waiting for the maintenance window opening at %s: %s", next.Format(time.RFC3339), ctx.Err()))
+	case <-schedule.after(next.Sub(now)):
+	}
+
+	return diags
+}
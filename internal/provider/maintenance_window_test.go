@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMaintenanceWindowContains(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %s", err)
+	}
+
+	overnight := maintenanceWindow{
+		days:     map[time.Weekday]bool{time.Friday: true},
+		start:    22 * 60,
+		end:      4 * 60,
+		location: newYork,
+	}
+	daytime := maintenanceWindow{
+		days:     map[time.Weekday]bool{time.Saturday: true},
+		start:    9 * 60,
+		end:      17 * 60,
+		location: time.UTC,
+	}
+
+	tests := []struct {
+		name     string
+		window   maintenanceWindow
+		at       time.Time
+		expected bool
+	}{
+		{"overnight before start", overnight, time.Date(2026, 10, 16, 21, 59, 0, 0, newYork), false},
+		{"overnight at start", overnight, time.Date(2026, 10, 16, 22, 0, 0, 0, newYork), true},
+		{"overnight after midnight", overnight, time.Date(2026, 10, 17, 3, 59, 0, 0, newYork), true},
+		{"overnight at end", overnight, time.Date(2026, 10, 17, 4, 0, 0, 0, newYork), false},
+		{"overnight other day", overnight, time.Date(2026, 10, 15, 23, 0, 0, 0, newYork), false},
+		{"overnight from utc", overnight, time.Date(2026, 10, 17, 3, 0, 0, 0, time.UTC), true},
+		{"daytime inside", daytime, time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC), true},
+		{"daytime wrong day", daytime, time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := tt.window.contains(tt.at)
+			if actual != tt.expected {
+				t.Errorf("contains(%s) = %t; want %t", tt.at, actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCheckMaintenanceWindow(t *testing.T) {
+	window := maintenanceWindow{
+		days:     map[time.Weekday]bool{time.Saturday: true},
+		start:    9 * 60,
+		end:      17 * 60,
+		location: time.UTC,
+	}
+	// Friday 2026-10-16 12:00 UTC, the window opens the next day at 09:00.
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	t.Run("not respected", func(t *testing.T) {
+		schedule := newMaintenanceSchedule(nil, false)
+		diags := checkMaintenanceWindow(context.Background(), schedule, types.BoolNull(), nil)
+		if diags.HasError() {
+			t.Fatalf("expected no error, got %v", diags)
+		}
+	})
+
+	t.Run("no windows configured", func(t *testing.T) {
+		schedule := newMaintenanceSchedule(nil, false)
+		diags := checkMaintenanceWindow(context.Background(), schedule, types.BoolValue(true), nil)
+		if !diags.HasError() {
+			t.Fatal("expected an error without maintenance windows")
+		}
+	})
+
+	t.Run("inside window", func(t *testing.T) {
+		schedule := newMaintenanceSchedule([]maintenanceWindow{window}, false)
+		schedule.now = func() time.Time { return now.Add(22 * time.Hour) }
+		diags := checkMaintenanceWindow(context.Background(), schedule, types.BoolValue(true), nil)
+		if diags.HasError() {
+			t.Fatalf("expected no error, got %v", diags)
+		}
+	})
+
+	t.Run("outside window fails", func(t *testing.T) {
+		schedule := newMaintenanceSchedule([]maintenanceWindow{window}, false)
+		schedule.now = func() time.Time { return now }
+		diags := checkMaintenanceWindow(context.Background(), schedule, types.BoolValue(true), nil)
+		if !diags.HasError() {
+			t.Fatal("expected an error outside the maintenance window")
+		}
+	})
+
+	t.Run("outside window waits", func(t *testing.T) {
+		schedule := newMaintenanceSchedule([]maintenanceWindow{window}, true)
+		schedule.now = func() time.Time { return now }
+		var waited time.Duration
+		schedule.after = func(d time.Duration) <-chan time.Time {
+			waited = d
+			ch := make(chan time.Time, 1)
+			ch <- now.Add(d)
+			return ch
+		}
+		diags := checkMaintenanceWindow(context.Background(), schedule, types.BoolValue(true), nil)
+		if diags.HasError() {
+			t.Fatalf("expected no error, got %v", diags)
+		}
+		if waited != 21*time.Hour {
+			t.Errorf("waited %s; want %s", waited, 21*time.Hour)
+		}
+	})
+
+	t.Run("wait cancelled", func(t *testing.T) {
+		schedule := newMaintenanceSchedule([]maintenanceWindow{window}, true)
+		schedule.now = func() time.Time { return now }
+		schedule.after = func(d time.Duration) <-chan time.Time { return make(chan time.Time) }
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		diags := checkMaintenanceWindow(ctx, schedule, types.BoolValue(true), nil)
+		if !diags.HasError() {
+			t.Fatal("expected an error when the wait is cancelled")
+		}
+	})
+}
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// importNamePrefix marks import IDs that name the object instead of giving
+// its ID, such as `name:Engineering`.
+const importNamePrefix = "name:"
+
+// nameLookup selects the single object of a singular data source by exact
+// name or by name regular expression.
+type nameLookup struct {
+	name      string
+	nameRegex *regexp.Regexp
+}
+
+// newNameLookup validates that exactly one of id, name and name_regex is set.
+// When id is set, the returned lookup is nil and the object should be read by
+// ID.
+func newNameLookup(id, name, nameRegex types.String) (*nameLookup, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	set := 0
+	for _, value := range []types.String{id, name, nameRegex} {
+		if !value.IsNull() {
+			set++
+		}
+	}
+	if set != 1 {
+		diags.AddError("Invalid Lookup", "Exactly one of `id`, `name` and `name_regex` must be set.")
+		return nil, diags
+	}
+
+	if !id.IsNull() {
+		return nil, diags
+	}
+
+	lookup := &nameLookup{name: name.ValueString()}
+	if !nameRegex.IsNull() {
+		re, err := regexp.Compile(nameRegex.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("name_regex"), "Invalid Name Regex", fmt.Sprintf("Unable to compile %q, got error: %s", nameRegex.ValueString(), err))
+			return nil, diags
+		}
+		lookup.nameRegex = re
+	}
+	return lookup, diags
+}
+
+func (l *nameLookup) matches(name string) bool {
+	if l.nameRegex != nil {
+		return l.nameRegex.MatchString(name)
+	}
+	return name == l.name
+}
+
+func (l *nameLookup) String() string {
+	if l.nameRegex != nil {
+		return fmt.Sprintf("name_regex %q", l.nameRegex.String())
+	}
+	return fmt.Sprintf("name %q", l.name)
+}
+
+// params returns the query parameters that narrow the listing down on the
+// server. The API matches names loosely, so results are still matched
+// exactly.
+func (l *nameLookup) params() url.Values {
+	params := url.Values{}
+	if l.nameRegex == nil {
+		params.Add("name", l.name)
+	}
+	return params
+}
+
+// findOne returns the single item of a listing that matches the lookup. kind
+// names the object type in diagnostics, such as "Custom Script".
+func findOne[T any](l *nameLookup, kind string, items iter.Seq2[T, error], nameOf func(T) string) (T, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var zero T
+
+	var matches []T
+	var names []string
+	for item, err := range items {
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to read %ss, got error: %s", strings.ToLower(kind), err))
+			return zero, diags
+		}
+		if l.matches(nameOf(item)) {
+			matches = append(matches, item)
+			names = append(names, fmt.Sprintf("%q", nameOf(item)))
+		}
+	}
+
+	switch len(matches) {
+	case 1:
+		return matches[0], diags
+	case 0:
+		diags.AddError(kind+" Not Found", fmt.Sprintf("No %s matches %s.", strings.ToLower(kind), l))
+	default:
+		diags.AddError("Multiple "+kind+"s Found", fmt.Sprintf("Expected exactly one %s to match %s, got %d: %s", strings.ToLower(kind), l, len(matches), strings.Join(names, ", ")))
+	}
+	return zero, diags
+}
+
+// resolveImportID resolves an import ID of the form `name:<name>` to the ID
+// of the single object with that exact name in the listing at endpoint. Other
+// import IDs are returned unchanged.
+func resolveImportID[T any](ctx context.Context, c *client.Client, kind, endpoint, importID string, fields func(T) (id, name string)) (string, diag.Diagnostics) {
+	name, ok := strings.CutPrefix(importID, importNamePrefix)
+	if !ok {
+		return importID, nil
+	}
+
+	lookup := &nameLookup{name: name}
+	items := resultsPages[T](ctx, c, endpoint, lookup.params())
+	item, diags := findOne(lookup, kind, items, func(item T) string {
+		_, name := fields(item)
+		return name
+	})
+	if diags.HasError() {
+		return "", diags
+	}
+
+	id, _ := fields(item)
+	return id, diags
+}
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNewNameLookup(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         types.String
+		lookupName types.String
+		nameRegex  types.String
+		wantLookup bool
+		wantError  bool
+	}{
+		{"id", types.StringValue("1"), types.StringNull(), types.StringNull(), false, false},
+		{"name", types.StringNull(), types.StringValue("Engineering"), types.StringNull(), true, false},
+		{"name_regex", types.StringNull(), types.StringNull(), types.StringValue("^Eng"), true, false},
+		{"none", types.StringNull(), types.StringNull(), types.StringNull(), false, true},
+		{"both", types.StringValue("1"), types.StringValue("Engineering"), types.StringNull(), false, true},
+		{"invalid regex", types.StringNull(), types.StringNull(), types.StringValue("("), false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookup, diags := newNameLookup(tt.id, tt.lookupName, tt.nameRegex)
+			if diags.HasError() != tt.wantError {
+				t.Errorf("Expected error %v, got %v", tt.wantError, diags)
+			}
+			if (lookup != nil) != tt.wantLookup {
+				t.Errorf("Expected lookup %v, got %v", tt.wantLookup, lookup)
+			}
+		})
+	}
+}
+
+func TestFindOne(t *testing.T) {
+	names := func(names ...string) iter.Seq2[string, error] {
+		return func(yield func(string, error) bool) {
+			for _, name := range names {
+				if !yield(name, nil) {
+					return
+				}
+			}
+		}
+	}
+	identity := func(name string) string { return name }
+
+	exact := &nameLookup{name: "Engineering"}
+	got, diags := findOne(exact, "Blueprint", names("Engineering Staging", "Engineering"), identity)
+	if diags.HasError() || got != "Engineering" {
+		t.Errorf("Expected an exact match, got %q, %v", got, diags)
+	}
+
+	_, diags = findOne(exact, "Blueprint", names("Sales"), identity)
+	if !diags.HasError() || diags[0].Summary() != "Blueprint Not Found" {
+		t.Errorf("Expected a not found error, got %v", diags)
+	}
+
+	lookup, _ := newNameLookup(types.StringNull(), types.StringNull(), types.StringValue("^Engineering"))
+	_, diags = findOne(lookup, "Blueprint", names("Engineering Staging", "Engineering"), identity)
+	if !diags.HasError() || diags[0].Summary() != "Multiple Blueprints Found" {
+		t.Errorf("Expected a multiple matches error, got %v", diags)
+	}
+
+	failing := func(yield func(string, error) bool) { yield("", errors.New("boom")) }
+	_, diags = findOne(exact, "Blueprint", failing, identity)
+	if !diags.HasError() || diags[0].Summary() != "Client Error" {
+		t.Errorf("Expected a client error, got %v", diags)
+	}
+}
+
+func TestResolveImportID(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("name"))
+		_, _ = w.Write([]byte(`{"results": [
+			{"id": "1", "name": "Finance Team"},
+			{"id": "2", "name": "Finance"}
+		]}`))
+	}))
+	defer server.Close()
+
+	c := client.NewClient(server.URL, "test-token")
+	fields := func(t client.Tag) (string, string) { return t.ID, t.Name }
+
+	id, diags := resolveImportID(context.Background(), c, "Tag", "/api/v1/tags", "name:Finance", fields)
+	if diags.HasError() || id != "2" {
+		t.Errorf("Expected ID 2, got %q, %v", id, diags)
+	}
+	if !slices.Equal(queries, []string{"Finance"}) {
+		t.Errorf("Expected one request filtered by name, got %v", queries)
+	}
+
+	id, diags = resolveImportID(context.Background(), c, "Tag", "/api/v1/tags", "3", fields)
+	if diags.HasError() || id != "3" {
+		t.Errorf("Expected the ID to pass through, got %q, %v", id, diags)
+	}
+	if len(queries) != 1 {
+		t.Errorf("Expected no request for a plain ID, got %d", len(queries))
+	}
+}
@@ -0,0 +1,357 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"math/big"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// prismQuery holds the parameters that narrow down the rows of a Prism
+// category.
+type prismQuery struct {
+	Category       string
+	BlueprintIDs   []string
+	DeviceFamilies []string
+	Filter         string
+	SortBy         string
+}
+
+// newPrismQuery reads the query parameters of a Prism category and validates
+// the filter and sort_by against its fields. Pass null values for parameters
+// the caller does not offer.
+func newPrismQuery(ctx context.Context, category string, blueprintIDs, deviceFamilies types.List, filter types.Object, sortBy types.String) (prismQuery, diag.Diagnostics) {
+	q := prismQuery{
+		Category: category,
+		SortBy:   sortBy.ValueString(),
+	}
+
+	var diags diag.Diagnostics
+	diags.Append(validatePrismSortBy(types.StringValue(category), sortBy)...)
+	var d diag.Diagnostics
+	q.Filter, d = buildPrismFilter(ctx, types.StringValue(category), filter)
+	diags.Append(d...)
+	if !blueprintIDs.IsNull() {
+		diags.Append(blueprintIDs.ElementsAs(ctx, &q.BlueprintIDs, false)...)
+	}
+	if !deviceFamilies.IsNull() {
+		diags.Append(deviceFamilies.ElementsAs(ctx, &q.DeviceFamilies, false)...)
+	}
+	return q, diags
+}
+
+func (q prismQuery) params() url.Values {
+	params := url.Values{}
+	if len(q.BlueprintIDs) > 0 {
+		params.Set("blueprint_ids", strings.Join(q.BlueprintIDs, ","))
+	}
+	if len(q.DeviceFamilies) > 0 {
+		params.Set("device_families", strings.Join(q.DeviceFamilies, ","))
+	}
+	if q.Filter != "" {
+		params.Set("filter", q.Filter)
+	}
+	if q.SortBy != "" {
+		params.Set("sort_by", q.SortBy)
+	}
+	return params
+}
+
+// body returns the query as the body of a Prism export request.
+func (q prismQuery) body() map[string]interface{} {
+	body := map[string]interface{}{
+		"category": q.Category,
+	}
+	if len(q.BlueprintIDs) > 0 {
+		body["blueprint_ids"] = q.BlueprintIDs
+	}
+	if len(q.DeviceFamilies) > 0 {
+		body["device_families"] = q.DeviceFamilies
+	}
+	if q.Filter != "" {
+		body["filter"] = json.RawMessage(q.Filter)
+	}
+	if q.SortBy != "" {
+		body["sort_by"] = q.SortBy
+	}
+	return body
+}
+
+// prismPages iterates over the rows of a Prism category starting at offset,
+// requesting pageSize rows at a time.
+func prismPages[T any](ctx context.Context, c *client.Client, q prismQuery, offset, pageSize int) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for {
+			params := q.params()
+			params.Set("limit", fmt.Sprintf("%d", pageSize))
+			params.Set("offset", fmt.Sprintf("%d", offset))
+
+			var response struct {
+				Data []T `json:"data"`
+			}
+			err := c.DoRequest(ctx, "GET", "/api/v1/prism/"+url.PathEscape(q.Category)+"?"+params.Encode(), nil, &response)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, row := range response.Data {
+				if !yield(row, nil) {
+					return
+				}
+			}
+			if len(response.Data) < pageSize {
+				return
+			}
+			offset += len(response.Data)
+		}
+	}
+}
+
+// readPrism collects the rows of a Prism category, honouring the optional
+// limit and offset arguments of the Prism data sources.
+func readPrism[T any](ctx context.Context, c *client.Client, q prismQuery, limit, offset types.Int64) ([]T, error) {
+	pageSize := listPageSize
+	if !limit.IsNull() {
+		if limit.ValueInt64() <= 0 {
+			return nil, nil
+		}
+		pageSize = int(min(limit.ValueInt64(), int64(listPageSize)))
+	}
+
+	var rows []T
+	for row, err := range prismPages[T](ctx, c, q, int(offset.ValueInt64()), pageSize) {
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+		if !limit.IsNull() && int64(len(rows)) >= limit.ValueInt64() {
+			break
+		}
+	}
+	return rows, nil
+}
+
+// prismRowsValue converts Prism rows into a dynamic tuple of objects. Every
+// object carries the keys of all rows so that rows can be used alike in
+// configuration; keys a row lacks are null.
+func prismRowsValue(ctx context.Context, rows []client.PrismEntry) (types.Dynamic, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	keyTypes := map[string]attr.Type{}
+	for _, row := range rows {
+		for key, value := range row {
+			if _, ok := keyTypes[key]; !ok {
+				keyTypes[key] = nil
+			}
+			if value != nil && keyTypes[key] == nil {
+				keyTypes[key] = prismValue(value).Type(ctx)
+			}
+		}
+	}
+	keys := make([]string, 0, len(keyTypes))
+	for key, t := range keyTypes {
+		if t == nil {
+			keyTypes[key] = types.StringType
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	elemTypes := make([]attr.Type, 0, len(rows))
+	elems := make([]attr.Value, 0, len(rows))
+	for _, row := range rows {
+		attrTypes := make(map[string]attr.Type, len(keys))
+		attrs := make(map[string]attr.Value, len(keys))
+		for _, key := range keys {
+			value := nullValue(ctx, keyTypes[key])
+			if v := row[key]; v != nil {
+				value = prismValue(v)
+			}
+			attrTypes[key] = value.Type(ctx)
+			attrs[key] = value
+		}
+
+		obj, d := types.ObjectValue(attrTypes, attrs)
+		diags.Append(d...)
+		elemTypes = append(elemTypes, obj.Type(ctx))
+		elems = append(elems, obj)
+	}
+	if diags.HasError() {
+		return types.DynamicNull(), diags
+	}
+
+	tuple, d := types.TupleValue(elemTypes, elems)
+	diags.Append(d...)
+	return types.DynamicValue(tuple), diags
+}
+
+// prismValue converts a decoded JSON value into a Terraform value. Numbers
+// stay numbers and nested objects and arrays become objects and tuples.
+func prismValue(v any) attr.Value {
+	switch v := v.(type) {
+	case nil:
+		return types.StringNull()
+	case string:
+		return types.StringValue(v)
+	case bool:
+		return types.BoolValue(v)
+	case float64:
+		return types.NumberValue(big.NewFloat(v))
+	case []any:
+		elemTypes := make([]attr.Type, 0, len(v))
+		elems := make([]attr.Value, 0, len(v))
+		for _, e := range v {
+			value := prismValue(e)
+			elemTypes = append(elemTypes, value.Type(context.Background()))
+			elems = append(elems, value)
+		}
+		return types.TupleValueMust(elemTypes, elems)
+	case map[string]any:
+		attrTypes := make(map[string]attr.Type, len(v))
+		attrs := make(map[string]attr.Value, len(v))
+		for key, e := range v {
+			value := prismValue(e)
+			attrTypes[key] = value.Type(context.Background())
+			attrs[key] = value
+		}
+		return types.ObjectValueMust(attrTypes, attrs)
+	default:
+		return types.StringValue(fmt.Sprintf("%v", v))
+	}
+}
+
+// nullValue returns the null value of type t.
+func nullValue(ctx context.Context, t attr.Type) attr.Value {
+	value, err := t.ValueFromTerraform(ctx, tftypes.NewValue(t.TerraformType(ctx), nil))
+	if err != nil {
+		return types.StringNull()
+	}
+	return value
+}
+
+// prismTimeLayouts are the timestamp formats found in Prism rows. Timestamps
+// without a zone are read as UTC.
+var prismTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05 MST",
+	"2006-01-02 15:04:05",
+	"Jan _2 15:04:05 2006 MST",
+	"2006-01-02",
+}
+
+// parsePrismTime reads a Prism timestamp in any of prismTimeLayouts.
+func parsePrismTime(v any) (time.Time, bool) {
+	s, ok := v.(string)
+	if !ok || s == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range prismTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// The prismRow helpers convert a field of a Prism row into a Terraform value.
+// Fields the row lacks, or that are null, become null values rather than zero
+// values, so that unreported data is distinguishable from reported data.
+
+// prismRowString returns a string field of a row. Numbers and booleans are
+// formatted as they appear in JSON.
+func prismRowString(row client.PrismEntry, field string) types.String {
+	switch v := row[field].(type) {
+	case nil:
+		return types.StringNull()
+	case string:
+		return types.StringValue(v)
+	case float64:
+		return types.StringValue(strconv.FormatFloat(v, 'f', -1, 64))
+	default:
+		return types.StringValue(fmt.Sprint(v))
+	}
+}
+
+// prismRowBool returns a boolean field of a row. The strings "true" and
+// "false" are accepted; other values are null.
+func prismRowBool(row client.PrismEntry, field string) types.Bool {
+	switch v := row[field].(type) {
+	case bool:
+		return types.BoolValue(v)
+	case string:
+		if b, err := strconv.ParseBool(v); err == nil {
+			return types.BoolValue(b)
+		}
+	}
+	return types.BoolNull()
+}
+
+// prismRowInt64 returns a whole number field of a row. Numeric strings are
+// accepted; fractions are truncated and other values are null.
+func prismRowInt64(row client.PrismEntry, field string) types.Int64 {
+	if f, ok := prismNumber(row[field]); ok {
+		return types.Int64Value(int64(f))
+	}
+	return types.Int64Null()
+}
+
+// prismRowFloat64 returns a number field of a row. Numeric strings are
+// accepted; other values are null.
+func prismRowFloat64(row client.PrismEntry, field string) types.Float64 {
+	if f, ok := prismNumber(row[field]); ok {
+		return types.Float64Value(f)
+	}
+	return types.Float64Null()
+}
+
+// prismRowTime returns a timestamp field of a row in RFC 3339 format and UTC.
+func prismRowTime(row client.PrismEntry, field string) types.String {
+	return timestampValue(prismRowString(row, field))
+}
+
+// timestampValue normalizes a timestamp in any of prismTimeLayouts to RFC 3339
+// in UTC. Null values stay null and values in an unknown format are returned
+// unchanged.
+func timestampValue(v types.String) types.String {
+	if t, ok := parsePrismTime(v.ValueString()); ok {
+		return types.StringValue(t.Format(time.RFC3339))
+	}
+	return v
+}
+
+// validatePrismDataSourceConfig validates the filter and sort_by of a Prism
+// data source with a fixed category, so that unknown fields are reported by
+// `terraform validate` rather than when the data source is read.
+func validatePrismDataSourceConfig(ctx context.Context, category string, config tfsdk.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var filter types.Object
+	var sortBy types.String
+	diags.Append(config.GetAttribute(ctx, path.Root("filter"), &filter)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("sort_by"), &sortBy)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(validatePrismSortBy(types.StringValue(category), sortBy)...)
+	_, d := buildPrismFilter(ctx, types.StringValue(category), filter)
+	diags.Append(d...)
+	return diags
+}
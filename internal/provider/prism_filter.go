@@ -0,0 +1,567 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	rsschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// prismFieldType is the JSON type of a Prism field. Filter values are sent
+// with the type of their field.
+type prismFieldType int
+
+const (
+	prismStringField prismFieldType = iota
+	prismBoolField
+	prismNumberField
+)
+
+// prismDeviceFields are reported by every Prism category.
+var prismDeviceFields = map[string]prismFieldType{
+	"device_id":     prismStringField,
+	"device__name":  prismStringField,
+	"serial_number": prismStringField,
+}
+
+// prismCategoryFields lists the fields of the Prism categories the provider
+// knows. Filters and sort_by on these categories are validated against it;
+// other categories are passed through unchecked.
+var prismCategoryFields = map[string]map[string]prismFieldType{
+	"activation_lock": {
+		"activation_lock_enabled": prismBoolField,
+	},
+	"application_firewall": {
+		"status":                    prismBoolField,
+		"block_all_incoming":        prismBoolField,
+		"stealth_mode":              prismBoolField,
+		"allow_signed_applications": prismBoolField,
+	},
+	"apps": {
+		"name":      prismStringField,
+		"version":   prismStringField,
+		"bundle_id": prismStringField,
+		"path":      prismStringField,
+	},
+	"cellular": {
+		"carrier":      prismStringField,
+		"iccid":        prismStringField,
+		"imei":         prismStringField,
+		"phone_number": prismStringField,
+	},
+	"certificates": {
+		"common_name":          prismStringField,
+		"identity_certificate": prismBoolField,
+		"subject":              prismStringField,
+		"issuer":               prismStringField,
+		"not_before":           prismStringField,
+		"not_after":            prismStringField,
+	},
+	"desktop_and_screensaver": {
+		"host_name":      prismStringField,
+		"os_version":     prismStringField,
+		"marketing_name": prismStringField,
+	},
+	"device_information": {
+		"model_name":      prismStringField,
+		"os_version":      prismStringField,
+		"device_capacity": prismNumberField,
+		"mdm_enabled":     prismBoolField,
+		"agent_installed": prismBoolField,
+	},
+	"filevault": {
+		"status":       prismBoolField,
+		"key_type":     prismStringField,
+		"key_escrowed": prismBoolField,
+	},
+	"gatekeeper_and_xprotect": {
+		"gatekeeper_status":            prismBoolField,
+		"trusted_developers":           prismBoolField,
+		"gatekeeper_version":           prismStringField,
+		"xprotect_version":             prismStringField,
+		"malware_removal_tool_version": prismStringField,
+	},
+	"installed_profiles": {
+		"profile_display_name": prismStringField,
+		"payload_identifier":   prismStringField,
+		"payload_uuid":         prismStringField,
+		"managed":              prismBoolField,
+	},
+	"kernel_extensions": {
+		"bundle_id": prismStringField,
+		"version":   prismStringField,
+		"path":      prismStringField,
+	},
+	"launch_agents_and_daemons": {
+		"label":     prismStringField,
+		"path":      prismStringField,
+		"is_loaded": prismBoolField,
+	},
+	"local_users": {
+		"username":       prismStringField,
+		"full_name":      prismStringField,
+		"type":           prismStringField,
+		"uid":            prismNumberField,
+		"logged_in":      prismBoolField,
+		"hidden_user":    prismBoolField,
+		"filevault_user": prismBoolField,
+	},
+	"startup_settings": {
+		"secure_boot_level": prismStringField,
+		"sip":               prismBoolField,
+		"ssv":               prismBoolField,
+	},
+	"system_extensions": {
+		"name":           prismStringField,
+		"identifier":     prismStringField,
+		"team_id":        prismStringField,
+		"state":          prismStringField,
+		"bundle_path":    prismStringField,
+		"is_mdm_managed": prismBoolField,
+	},
+	"transparency_database": {
+		"application": prismStringField,
+		"service":     prismStringField,
+		"status":      prismStringField,
+		"local_user":  prismStringField,
+	},
+}
+
+// prismField looks up a field of a category. known reports whether the
+// category is in prismCategoryFields; ok reports whether it has the field.
+func prismField(category, field string) (t prismFieldType, ok, known bool) {
+	fields, known := prismCategoryFields[category]
+	if !known {
+		return prismStringField, false, false
+	}
+	if t, ok := prismDeviceFields[field]; ok {
+		return t, true, true
+	}
+	t, ok = fields[field]
+	return t, ok, true
+}
+
+// prismFieldNames returns the sorted field names of a known category.
+func prismFieldNames(category string) []string {
+	var names []string
+	for name := range prismDeviceFields {
+		names = append(names, name)
+	}
+	for name := range prismCategoryFields[category] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// prismFilterOperators are the comparison operators of Prism filters.
+// Operators marked true compare against a list of values.
+var prismFilterOperators = map[string]bool{
+	"eq":       false,
+	"neq":      false,
+	"gt":       false,
+	"gte":      false,
+	"lt":       false,
+	"lte":      false,
+	"like":     false,
+	"not_like": false,
+	"in":       true,
+	"not_in":   true,
+}
+
+type prismFilterModel struct {
+	Match      types.String                `tfsdk:"match"`
+	Conditions []prismFilterConditionModel `tfsdk:"conditions"`
+	Groups     []prismFilterGroupModel     `tfsdk:"groups"`
+}
+
+type prismFilterGroupModel struct {
+	Match      types.String                `tfsdk:"match"`
+	Conditions []prismFilterConditionModel `tfsdk:"conditions"`
+}
+
+type prismFilterConditionModel struct {
+	Field    types.String `tfsdk:"field"`
+	Operator types.String `tfsdk:"operator"`
+	Value    types.String `tfsdk:"value"`
+	Values   types.List   `tfsdk:"values"`
+}
+
+// Descriptions shared by the data source and resource filter schemas.
+const (
+	prismFilterDescription          = "Only return rows matching these conditions. The filter is sent to the Prism API, and its fields are checked against the fields of known categories at plan time."
+	prismFilterMatchDescription     = "Whether rows must match `all` (the default) or `any` of the conditions and groups."
+	prismFilterGroupsDescription    = "Groups of conditions, each combined with its own `match`. Use a group to nest `any` conditions in an `all` filter or the other way around."
+	prismFilterFieldDescription     = "The field to compare, such as `filevault_user`."
+	prismFilterOperatorDescription  = "The comparison: `eq`, `neq`, `gt`, `gte`, `lt`, `lte`, `like`, `not_like`, `in` or `not_in`."
+	prismFilterValueDescription     = "The value to compare with, required unless the operator is `in` or `not_in`. Values are sent with the type of their field; for fields the provider does not know, `true`, `false` and numbers are sent as booleans and numbers."
+	prismFilterValuesDescription    = "The values to compare with, required for the `in` and `not_in` operators."
+	prismSortByDescription          = "The field to sort rows by. Prefix the field with `-` to sort in descending order."
+	prismFilterConditionDescription = "The conditions of the filter."
+)
+
+func prismFilterDataSourceAttribute() dsschema.SingleNestedAttribute {
+	conditions := dsschema.ListNestedAttribute{
+		Optional:            true,
+		MarkdownDescription: prismFilterConditionDescription,
+		NestedObject: dsschema.NestedAttributeObject{
+			Attributes: map[string]dsschema.Attribute{
+				"field":    dsschema.StringAttribute{Required: true, MarkdownDescription: prismFilterFieldDescription},
+				"operator": dsschema.StringAttribute{Required: true, MarkdownDescription: prismFilterOperatorDescription},
+				"value":    dsschema.StringAttribute{Optional: true, MarkdownDescription: prismFilterValueDescription},
+				"values":   dsschema.ListAttribute{Optional: true, ElementType: types.StringType, MarkdownDescription: prismFilterValuesDescription},
+			},
+		},
+	}
+	match := dsschema.StringAttribute{Optional: true, MarkdownDescription: prismFilterMatchDescription}
+
+	return dsschema.SingleNestedAttribute{
+		Optional:            true,
+		MarkdownDescription: prismFilterDescription,
+		Attributes: map[string]dsschema.Attribute{
+			"match":      match,
+			"conditions": conditions,
+			"groups": dsschema.ListNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: prismFilterGroupsDescription,
+				NestedObject: dsschema.NestedAttributeObject{
+					Attributes: map[string]dsschema.Attribute{
+						"match":      match,
+						"conditions": conditions,
+					},
+				},
+			},
+		},
+	}
+}
+
+// prismFilterResourceAttribute is the filter of Prism exports. Exports cannot
+// be updated, so changing the filter replaces the export.
+func prismFilterResourceAttribute() rsschema.SingleNestedAttribute {
+	conditions := rsschema.ListNestedAttribute{
+		Optional:            true,
+		MarkdownDescription: prismFilterConditionDescription,
+		NestedObject: rsschema.NestedAttributeObject{
+			Attributes: map[string]rsschema.Attribute{
+				"field":    rsschema.StringAttribute{Required: true, MarkdownDescription: prismFilterFieldDescription},
+				"operator": rsschema.StringAttribute{Required: true, MarkdownDescription: prismFilterOperatorDescription},
+				"value":    rsschema.StringAttribute{Optional: true, MarkdownDescription: prismFilterValueDescription},
+				"values":   rsschema.ListAttribute{Optional: true, ElementType: types.StringType, MarkdownDescription: prismFilterValuesDescription},
+			},
+		},
+	}
+	match := rsschema.StringAttribute{Optional: true, MarkdownDescription: prismFilterMatchDescription}
+
+	return rsschema.SingleNestedAttribute{
+		Optional:            true,
+		MarkdownDescription: prismFilterDescription,
+		PlanModifiers: []planmodifier.Object{
+			objectplanmodifier.RequiresReplace(),
+		},
+		Attributes: map[string]rsschema.Attribute{
+			"match":      match,
+			"conditions": conditions,
+			"groups": rsschema.ListNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: prismFilterGroupsDescription,
+				NestedObject: rsschema.NestedAttributeObject{
+					Attributes: map[string]rsschema.Attribute{
+						"match":      match,
+						"conditions": conditions,
+					},
+				},
+			},
+		},
+	}
+}
+
+// isFullyKnown reports whether v and all values nested in it are known.
+func isFullyKnown(ctx context.Context, v attr.Value) bool {
+	tv, err := v.ToTerraformValue(ctx)
+	return err == nil && tv.IsFullyKnown()
+}
+
+// buildPrismFilter validates a filter against the fields of category and
+// serializes it into the Prism API's JSON filter format. It returns an empty
+// string for a null filter, or when the filter or category is not yet known,
+// so that it can be used during validation.
+func buildPrismFilter(ctx context.Context, category types.String, filter types.Object) (string, diag.Diagnostics) {
+	expr, diags := prismFilterExpr(ctx, path.Root("filter"), category, filter)
+	if expr == nil || diags.HasError() {
+		return "", diags
+	}
+
+	encoded, err := json.Marshal(expr)
+	if err != nil {
+		diags.AddAttributeError(path.Root("filter"), "Invalid Filter", fmt.Sprintf("Unable to encode filter, got error: %s", err))
+		return "", diags
+	}
+	return string(encoded), diags
+}
+
+// prismFilterExpr validates the filter at root against the fields of
+// category and returns it as a tree of `and`, `or` and comparison maps, the
+// shape of the Prism API's JSON filter. It returns nil for a null filter, or
+// when the filter or category is not yet known.
+func prismFilterExpr(ctx context.Context, root path.Path, category types.String, filter types.Object) (any, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if filter.IsNull() || !isFullyKnown(ctx, filter) || category.IsUnknown() {
+		return nil, diags
+	}
+
+	var model prismFilterModel
+	diags.Append(filter.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	b := prismFilterBuilder{category: category.ValueString()}
+
+	var children []any
+	for i, condition := range model.Conditions {
+		children = append(children, b.condition(root.AtName("conditions").AtListIndex(i), condition))
+	}
+	for i, group := range model.Groups {
+		groupPath := root.AtName("groups").AtListIndex(i)
+		var conditions []any
+		for j, condition := range group.Conditions {
+			conditions = append(conditions, b.condition(groupPath.AtName("conditions").AtListIndex(j), condition))
+		}
+		if len(conditions) == 0 {
+			b.diags.AddAttributeError(groupPath, "Invalid Filter", "A filter group must have at least one condition.")
+		}
+		children = append(children, b.combine(groupPath.AtName("match"), group.Match, conditions))
+	}
+	if len(children) == 0 {
+		b.diags.AddAttributeError(root, "Invalid Filter", "A filter must have at least one condition or group.")
+	}
+
+	expr := b.combine(root.AtName("match"), model.Match, children)
+	diags.Append(b.diags...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	return expr, diags
+}
+
+// matchPrismExpr evaluates a filter expression from prismFilterExpr against a
+// row, the way the Prism API applies filters. Comparisons against fields the
+// row lacks do not match; `like` matches substrings ignoring case.
+func matchPrismExpr(expr any, row client.PrismEntry) bool {
+	node, ok := expr.(map[string]any)
+	if !ok {
+		return false
+	}
+
+	for key, value := range node {
+		switch key {
+		case "and":
+			for _, child := range value.([]any) {
+				if !matchPrismExpr(child, row) {
+					return false
+				}
+			}
+		case "or":
+			matched := false
+			for _, child := range value.([]any) {
+				if matchPrismExpr(child, row) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return false
+			}
+		default:
+			for operator, want := range value.(map[string]any) {
+				if !matchPrismCondition(row[key], operator, want) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+func matchPrismCondition(got any, operator string, want any) bool {
+	if got == nil {
+		return false
+	}
+
+	switch operator {
+	case "eq":
+		return prismCompare(got, want) == 0
+	case "neq":
+		return prismCompare(got, want) != 0
+	case "gt":
+		return prismCompare(got, want) > 0
+	case "gte":
+		return prismCompare(got, want) >= 0
+	case "lt":
+		return prismCompare(got, want) < 0
+	case "lte":
+		return prismCompare(got, want) <= 0
+	case "like":
+		return strings.Contains(strings.ToLower(fmt.Sprint(got)), strings.ToLower(fmt.Sprint(want)))
+	case "not_like":
+		return !strings.Contains(strings.ToLower(fmt.Sprint(got)), strings.ToLower(fmt.Sprint(want)))
+	case "in", "not_in":
+		found := false
+		for _, w := range want.([]any) {
+			if prismCompare(got, w) == 0 {
+				found = true
+				break
+			}
+		}
+		return found == (operator == "in")
+	}
+	return false
+}
+
+// prismCompare orders two JSON values. Values that both read as numbers are
+// compared numerically and everything else as text.
+func prismCompare(a, b any) int {
+	af, aok := prismNumber(a)
+	bf, bok := prismNumber(b)
+	if aok && bok {
+		switch {
+		case af < bf:
+			return -1
+		case af > bf:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+func prismNumber(v any) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// validatePrismSortBy checks that sort_by names a field of category.
+func validatePrismSortBy(category, sortBy types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if sortBy.IsNull() || sortBy.IsUnknown() || category.IsUnknown() {
+		return diags
+	}
+
+	field := strings.TrimPrefix(sortBy.ValueString(), "-")
+	if _, ok, known := prismField(category.ValueString(), field); known && !ok {
+		diags.AddAttributeError(path.Root("sort_by"), "Unknown Prism Field", unknownPrismFieldDetail(category.ValueString(), field))
+	}
+	return diags
+}
+
+func unknownPrismFieldDetail(category, field string) string {
+	return fmt.Sprintf("The %s category has no field %q. Known fields: %s.", category, field, strings.Join(prismFieldNames(category), ", "))
+}
+
+type prismFilterBuilder struct {
+	category string
+	diags    diag.Diagnostics
+}
+
+// condition serializes a condition as `{"<field>": {"<operator>": <value>}}`.
+func (b *prismFilterBuilder) condition(p path.Path, c prismFilterConditionModel) any {
+	field := c.Field.ValueString()
+	fieldType, ok, known := prismField(b.category, field)
+	if known && !ok {
+		b.diags.AddAttributeError(p.AtName("field"), "Unknown Prism Field", unknownPrismFieldDetail(b.category, field))
+	}
+
+	operator := c.Operator.ValueString()
+	multiple, ok := prismFilterOperators[operator]
+	if !ok {
+		b.diags.AddAttributeError(p.AtName("operator"), "Invalid Filter Operator", fmt.Sprintf("Unknown operator %q; expected one of eq, neq, gt, gte, lt, lte, like, not_like, in or not_in.", operator))
+		return nil
+	}
+
+	if !multiple {
+		if c.Value.IsNull() || !c.Values.IsNull() {
+			b.diags.AddAttributeError(p, "Invalid Filter Condition", fmt.Sprintf("The %s operator takes `value` and not `values`.", operator))
+			return nil
+		}
+		return map[string]any{field: map[string]any{operator: b.value(p.AtName("value"), fieldType, known, c.Value.ValueString())}}
+	}
+
+	if c.Values.IsNull() || !c.Value.IsNull() {
+		b.diags.AddAttributeError(p, "Invalid Filter Condition", fmt.Sprintf("The %s operator takes `values` and not `value`.", operator))
+		return nil
+	}
+	var raw []string
+	b.diags.Append(c.Values.ElementsAs(context.Background(), &raw, false)...)
+	values := make([]any, 0, len(raw))
+	for i, v := range raw {
+		values = append(values, b.value(p.AtName("values").AtListIndex(i), fieldType, known, v))
+	}
+	return map[string]any{field: map[string]any{operator: values}}
+}
+
+// value converts a filter value to the JSON type of its field.
+func (b *prismFilterBuilder) value(p path.Path, t prismFieldType, known bool, v string) any {
+	if !known {
+		if v == "true" || v == "false" {
+			return v == "true"
+		}
+		if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+			return parsed
+		}
+		return v
+	}
+
+	switch t {
+	case prismBoolField:
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			b.diags.AddAttributeError(p, "Invalid Filter Value", fmt.Sprintf("Expected `true` or `false`, got %q.", v))
+		}
+		return parsed
+	case prismNumberField:
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			b.diags.AddAttributeError(p, "Invalid Filter Value", fmt.Sprintf("Expected a number, got %q.", v))
+		}
+		return parsed
+	default:
+		return v
+	}
+}
+
+// combine joins expressions with `and` or `or` according to match. A single
+// expression is returned as is.
+func (b *prismFilterBuilder) combine(p path.Path, match types.String, exprs []any) any {
+	operator := "and"
+	switch match.ValueString() {
+	case "", "all":
+	case "any":
+		operator = "or"
+	default:
+		b.diags.AddAttributeError(p, "Invalid Filter Match", fmt.Sprintf("`match` must be `all` or `any`, got %q.", match.ValueString()))
+	}
+
+	if len(exprs) == 1 {
+		return exprs[0]
+	}
+	return map[string]any{operator: exprs}
+}
@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func prismFilterValue(t *testing.T, model prismFilterModel) types.Object {
+	t.Helper()
+	attrTypes := prismFilterDataSourceAttribute().GetType().(types.ObjectType).AttrTypes
+	value, diags := types.ObjectValueFrom(context.Background(), attrTypes, model)
+	if diags.HasError() {
+		t.Fatalf("Expected no error, got %v", diags)
+	}
+	return value
+}
+
+func prismCondition(field, operator string, value string, values ...string) prismFilterConditionModel {
+	c := prismFilterConditionModel{
+		Field:    types.StringValue(field),
+		Operator: types.StringValue(operator),
+		Value:    types.StringNull(),
+		Values:   types.ListNull(types.StringType),
+	}
+	if values != nil {
+		elems := make([]types.String, 0, len(values))
+		for _, v := range values {
+			elems = append(elems, types.StringValue(v))
+		}
+		c.Values, _ = types.ListValueFrom(context.Background(), types.StringType, elems)
+	} else {
+		c.Value = types.StringValue(value)
+	}
+	return c
+}
+
+func TestBuildPrismFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		category string
+		model    prismFilterModel
+		want     string
+		wantErr  string
+	}{
+		{
+			name:     "single condition",
+			category: "filevault",
+			model: prismFilterModel{
+				Conditions: []prismFilterConditionModel{prismCondition("status", "eq", "true")},
+			},
+			want: `{"status":{"eq":true}}`,
+		},
+		{
+			name:     "nested group",
+			category: "local_users",
+			model: prismFilterModel{
+				Conditions: []prismFilterConditionModel{prismCondition("uid", "gte", "501")},
+				Groups: []prismFilterGroupModel{{
+					Match: types.StringValue("any"),
+					Conditions: []prismFilterConditionModel{
+						prismCondition("hidden_user", "eq", "true"),
+						prismCondition("username", "in", "", "admin", "root"),
+					},
+				}},
+			},
+			want: `{"and":[{"uid":{"gte":501}},{"or":[{"hidden_user":{"eq":true}},{"username":{"in":["admin","root"]}}]}]}`,
+		},
+		{
+			name:     "unknown category",
+			category: "future_category",
+			model: prismFilterModel{
+				Match: types.StringValue("any"),
+				Conditions: []prismFilterConditionModel{
+					prismCondition("enabled", "eq", "false"),
+					prismCondition("name", "like", "Corp"),
+				},
+			},
+			want: `{"or":[{"enabled":{"eq":false}},{"name":{"like":"Corp"}}]}`,
+		},
+		{
+			name:     "unknown field",
+			category: "filevault",
+			model: prismFilterModel{
+				Conditions: []prismFilterConditionModel{prismCondition("enabled", "eq", "true")},
+			},
+			wantErr: "Unknown Prism Field",
+		},
+		{
+			name:     "invalid bool",
+			category: "filevault",
+			model: prismFilterModel{
+				Conditions: []prismFilterConditionModel{prismCondition("status", "eq", "on")},
+			},
+			wantErr: "Invalid Filter Value",
+		},
+		{
+			name:     "values for single value operator",
+			category: "filevault",
+			model: prismFilterModel{
+				Conditions: []prismFilterConditionModel{prismCondition("key_type", "eq", "", "a")},
+			},
+			wantErr: "Invalid Filter Condition",
+		},
+		{
+			name:     "empty group",
+			category: "filevault",
+			model: prismFilterModel{
+				Conditions: []prismFilterConditionModel{prismCondition("status", "eq", "true")},
+				Groups: []prismFilterGroupModel{{
+					Match:      types.StringValue("any"),
+					Conditions: []prismFilterConditionModel{},
+				}},
+			},
+			wantErr: "Invalid Filter",
+		},
+		{
+			name:     "empty",
+			category: "filevault",
+			model:    prismFilterModel{},
+			wantErr:  "Invalid Filter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := buildPrismFilter(context.Background(), types.StringValue(tt.category), prismFilterValue(t, tt.model))
+			if tt.wantErr != "" {
+				if !diags.HasError() || diags.Errors()[0].Summary() != tt.wantErr {
+					t.Fatalf("Expected %q error, got %v", tt.wantErr, diags)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("Expected no error, got %v", diags)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestValidatePrismSortBy(t *testing.T) {
+	if diags := validatePrismSortBy(types.StringValue("local_users"), types.StringValue("-username")); diags.HasError() {
+		t.Errorf("Expected -username to be valid, got %v", diags)
+	}
+	if diags := validatePrismSortBy(types.StringValue("local_users"), types.StringValue("name")); !diags.HasError() {
+		t.Error("Expected an error for an unknown field")
+	}
+	if diags := validatePrismSortBy(types.StringValue("future_category"), types.StringValue("name")); diags.HasError() {
+		t.Errorf("Expected fields of unknown categories to pass, got %v", diags)
+	}
+}
@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPrismPages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/prism/local_users" {
+			t.Errorf("Expected the local_users category, got %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if got := query.Get("blueprint_ids"); got != "bp1,bp2" {
+			t.Errorf("Expected blueprint_ids=bp1,bp2, got %q", got)
+		}
+		if got := query.Get("sort_by"); got != "-username" {
+			t.Errorf("Expected sort_by=-username, got %q", got)
+		}
+		switch query.Get("offset") {
+		case "0":
+			_, _ = w.Write([]byte(`{"data": [{"username": "a"}, {"username": "b"}]}`))
+		case "2":
+			_, _ = w.Write([]byte(`{"data": [{"username": "c"}]}`))
+		default:
+			t.Errorf("Unexpected offset %s", query.Get("offset"))
+		}
+	}))
+	defer server.Close()
+
+	c := client.NewClient(server.URL, "test-token")
+	q := prismQuery{Category: "local_users", BlueprintIDs: []string{"bp1", "bp2"}, SortBy: "-username"}
+
+	var usernames []any
+	for row, err := range prismPages[client.PrismEntry](context.Background(), c, q, 0, 2) {
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		usernames = append(usernames, row["username"])
+	}
+	if fmt.Sprint(usernames) != "[a b c]" {
+		t.Errorf("Expected rows a, b and c, got %v", usernames)
+	}
+
+	rows, err := readPrism[client.PrismEntry](context.Background(), c, q, types.Int64Value(1), types.Int64Null())
+	if err != nil || len(rows) != 1 {
+		t.Errorf("Expected one row, got %v, %v", rows, err)
+	}
+}
+
+func TestPrismRowsValue(t *testing.T) {
+	rows := []client.PrismEntry{
+		{"device_id": "1", "uid": float64(501), "hidden_user": nil},
+		{"device_id": "2", "hidden_user": true, "tags": []any{"a", "b"}},
+	}
+
+	value, diags := prismRowsValue(context.Background(), rows)
+	if diags.HasError() {
+		t.Fatalf("Expected no error, got %v", diags)
+	}
+
+	tuple, ok := value.UnderlyingValue().(types.Tuple)
+	if !ok || len(tuple.Elements()) != 2 {
+		t.Fatalf("Expected a tuple of two rows, got %v", value)
+	}
+
+	first := tuple.Elements()[0].(types.Object).Attributes()
+	if uid, _ := first["uid"].(types.Number).ValueBigFloat().Int64(); uid != 501 {
+		t.Errorf("Expected uid 501, got %v", first["uid"])
+	}
+	if hidden, ok := first["hidden_user"].(types.Bool); !ok || !hidden.IsNull() {
+		t.Errorf("Expected a null bool for hidden_user, got %v", first["hidden_user"])
+	}
+	if !first["tags"].IsNull() {
+		t.Errorf("Expected missing tags to be null, got %v", first["tags"])
+	}
+
+	second := tuple.Elements()[1].(types.Object).Attributes()
+	if !second["uid"].Equal(types.NumberNull()) {
+		t.Errorf("Expected missing uid to be a null number, got %v", second["uid"])
+	}
+	if !second["hidden_user"].Equal(types.BoolValue(true)) {
+		t.Errorf("Expected hidden_user true, got %v", second["hidden_user"])
+	}
+}
+
+func TestPrismRowHelpers(t *testing.T) {
+	row := client.PrismEntry{
+		"name":        "Mac-1",
+		"capacity":    float64(1000000),
+		"uid":         "501",
+		"hidden_user": "false",
+		"sip":         true,
+		"missing":     nil,
+		"last_seen":   "2024-03-01 12:30:00 +0100",
+		"free_text":   "yesterday",
+	}
+
+	if got := prismRowString(row, "capacity"); got.ValueString() != "1000000" {
+		t.Errorf("Expected numbers formatted without an exponent, got %v", got)
+	}
+	if got := prismRowString(row, "missing"); !got.IsNull() {
+		t.Errorf("Expected a null string for a null field, got %v", got)
+	}
+	if got := prismRowString(row, "absent"); !got.IsNull() {
+		t.Errorf("Expected a null string for an absent field, got %v", got)
+	}
+	if got := prismRowInt64(row, "uid"); got.ValueInt64() != 501 {
+		t.Errorf("Expected uid 501, got %v", got)
+	}
+	if got := prismRowInt64(row, "name"); !got.IsNull() {
+		t.Errorf("Expected a null number for a non-numeric field, got %v", got)
+	}
+	if got := prismRowFloat64(row, "capacity"); got.ValueFloat64() != 1000000 {
+		t.Errorf("Expected capacity 1000000, got %v", got)
+	}
+	if got := prismRowBool(row, "hidden_user"); got.IsNull() || got.ValueBool() {
+		t.Errorf("Expected hidden_user false, got %v", got)
+	}
+	if got := prismRowBool(row, "sip"); !got.ValueBool() {
+		t.Errorf("Expected sip true, got %v", got)
+	}
+	if got := prismRowBool(row, "absent"); !got.IsNull() {
+		t.Errorf("Expected a null bool for an absent field, got %v", got)
+	}
+	if got := prismRowTime(row, "last_seen"); got.ValueString() != "2024-03-01T11:30:00Z" {
+		t.Errorf("Expected the timestamp normalized to UTC, got %v", got)
+	}
+	if got := prismRowTime(row, "free_text"); got.ValueString() != "yesterday" {
+		t.Errorf("Expected an unknown format to be kept, got %v", got)
+	}
+	if got := prismRowTime(row, "absent"); !got.IsNull() {
+		t.Errorf("Expected a null timestamp for an absent field, got %v", got)
+	}
+}
+
+func TestPrismDataSourceValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	d := &prismLocalUsersDataSource{}
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	validate := func(filter types.Object, sortBy types.String) diag.Diagnostics {
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		diags := state.Set(ctx, &prismLocalUsersDataSourceModel{
+			ID:     types.StringNull(),
+			Limit:  types.Int64Null(),
+			Offset: types.Int64Null(),
+			Filter: filter,
+			SortBy: sortBy,
+		})
+		if diags.HasError() {
+			t.Fatalf("Unexpected error building config: %v", diags)
+		}
+		var resp datasource.ValidateConfigResponse
+		d.ValidateConfig(ctx, datasource.ValidateConfigRequest{Config: tfsdk.Config{Raw: state.Raw, Schema: schemaResp.Schema}}, &resp)
+		return resp.Diagnostics
+	}
+
+	valid := prismFilterValue(t, prismFilterModel{
+		Match:      types.StringNull(),
+		Conditions: []prismFilterConditionModel{prismCondition("hidden_user", "eq", "true")},
+	})
+	if diags := validate(valid, types.StringValue("-username")); diags.HasError() {
+		t.Errorf("Expected a valid config, got %v", diags)
+	}
+
+	invalid := prismFilterValue(t, prismFilterModel{
+		Match:      types.StringNull(),
+		Conditions: []prismFilterConditionModel{prismCondition("hidden", "eq", "true")},
+	})
+	if diags := validate(invalid, types.StringNull()); !diags.HasError() {
+		t.Error("Expected an unknown filter field to be rejected")
+	}
+	if diags := validate(types.ObjectNull(valid.AttributeTypes(ctx)), types.StringValue("name")); !diags.HasError() {
+		t.Error("Expected an unknown sort_by field to be rejected")
+	}
+}
@@ -2,8 +2,10 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/MScottBlake/terraform-provider-iru/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework/action"
@@ -15,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
 // Ensure IruProvider satisfies various provider interfaces.
@@ -32,8 +35,28 @@ type IruProvider struct {
 
 // IruProviderModel describes the provider data model.
 type IruProviderModel struct {
-	APIURL   types.String `tfsdk:"api_url"`
-	APIToken types.String `tfsdk:"api_token"`
+	APIURL                  types.String `tfsdk:"api_url"`
+	APIToken                types.String `tfsdk:"api_token"`
+	MaintenanceWindows      types.List   `tfsdk:"maintenance_windows"`
+	MaintenanceWindowAction types.String `tfsdk:"maintenance_window_action"`
+	DryRun                  types.Bool   `tfsdk:"dry_run"`
+	ActionNotes             types.Object `tfsdk:"action_notes"`
+}
+
+// actionNotesModel describes the provider `action_notes` attribute.
+type actionNotesModel struct {
+	RunID              types.String `tfsdk:"run_id"`
+	RunIDEnv           types.String `tfsdk:"run_id_env"`
+	SensitiveArguments types.List   `tfsdk:"sensitive_arguments"`
+}
+
+// actionData is passed to actions as their provider data. Besides the API
+// client, actions need provider settings such as the maintenance windows.
+type actionData struct {
+	client      *client.Client
+	maintenance *maintenanceSchedule
+	dryRun      bool
+	notes       *actionNoteSettings
 }
 
 func (p *IruProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -53,6 +76,58 @@ func (p *IruProvider) Schema(ctx context.Context, req provider.SchemaRequest, re
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"dry_run": schema.BoolAttribute{
+				MarkdownDescription: "When `true`, read requests are sent normally, but create, update and delete requests are logged as warnings instead of being sent. Actions report the requests they would have sent as warnings. Resources fail with an error describing the request they would have sent, so no placeholder values are written to state.",
+				Optional:            true,
+			},
+			"action_notes": schema.SingleNestedAttribute{
+				MarkdownDescription: "When set, a note is added to the device after every successful `iru_device_action_*` action. The note records the action name, its arguments, a timestamp and an optional run identifier. PINs and other secrets are never included.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"run_id": schema.StringAttribute{
+						MarkdownDescription: "An identifier for the Terraform run, such as a CI job URL, to include in each note.",
+						Optional:            true,
+					},
+					"run_id_env": schema.StringAttribute{
+						MarkdownDescription: "The name of an environment variable to read the run identifier from when `run_id` is not set, e.g. `CI_JOB_URL`.",
+						Optional:            true,
+					},
+					"sensitive_arguments": schema.ListAttribute{
+						MarkdownDescription: "Action arguments whose values are never included in notes. Defaults to `message`, `footnote` and `phone_number`.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+				},
+			},
+			"maintenance_windows": schema.ListNestedAttribute{
+				MarkdownDescription: "Weekly windows during which disruptive device actions may run. Actions only honor these windows when their `respect_maintenance_window` argument is `true`.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"days": schema.ListAttribute{
+							MarkdownDescription: "Days of the week on which the window opens, e.g. `saturday` or `sat`.",
+							Required:            true,
+							ElementType:         types.StringType,
+						},
+						"start": schema.StringAttribute{
+							MarkdownDescription: "The time the window opens, in 24-hour `HH:MM` format.",
+							Required:            true,
+						},
+						"end": schema.StringAttribute{
+							MarkdownDescription: "The time the window closes, in 24-hour `HH:MM` format. If `end` is not after `start`, the window closes on the following day.",
+							Required:            true,
+						},
+						"time_zone": schema.StringAttribute{
+							MarkdownDescription: "The IANA time zone of `start` and `end`, e.g. `America/New_York`. Defaults to `UTC`.",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"maintenance_window_action": schema.StringAttribute{
+				MarkdownDescription: "What an action that respects maintenance windows does when invoked outside of one: `fail` (default) returns an error, `wait` blocks until the next window opens.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -90,11 +165,77 @@ func (p *IruProvider) Configure(ctx context.Context, req provider.ConfigureReque
 	}
 
 	c := client.NewClient(apiURL, apiToken)
+	if data.DryRun.ValueBool() {
+		c.Interceptors = append(c.Interceptors, client.DryRunInterceptor)
+	}
+
+	var windows []maintenanceWindow
+	if !data.MaintenanceWindows.IsNull() {
+		var windowModels []maintenanceWindowModel
+		resp.Diagnostics.Append(data.MaintenanceWindows.ElementsAs(ctx, &windowModels, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		for i, m := range windowModels {
+			w, err := parseMaintenanceWindow(ctx, m)
+			if err != nil {
+				resp.Diagnostics.AddError("Invalid Maintenance Window", fmt.Sprintf("maintenance_windows[%d]: %s", i, err))
+				return
+			}
+			windows = append(windows, w)
+		}
+	}
+
+	wait := false
+	switch data.MaintenanceWindowAction.ValueString() {
+	case "", "fail":
+	case "wait":
+		wait = true
+	default:
+		resp.Diagnostics.AddError("Invalid Maintenance Window Action", "The 'maintenance_window_action' provider attribute must be either \"fail\" or \"wait\".")
+		return
+	}
+
+	var notes *actionNoteSettings
+	if !data.ActionNotes.IsNull() {
+		var notesConfig actionNotesModel
+		resp.Diagnostics.Append(data.ActionNotes.As(ctx, &notesConfig, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		sensitive := defaultSensitiveArguments
+		if !notesConfig.SensitiveArguments.IsNull() {
+			sensitive = nil
+			resp.Diagnostics.Append(notesConfig.SensitiveArguments.ElementsAs(ctx, &sensitive, false)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+
+		notes = &actionNoteSettings{
+			runID:              notesConfig.RunID.ValueString(),
+			sensitiveArguments: map[string]bool{},
+			now:                time.Now,
+		}
+		if notes.runID == "" && notesConfig.RunIDEnv.ValueString() != "" {
+			notes.runID = os.Getenv(notesConfig.RunIDEnv.ValueString())
+		}
+		for _, name := range sensitive {
+			notes.sensitiveArguments[name] = true
+		}
+	}
 
 	resp.DataSourceData = c
 	resp.ResourceData = c
 	resp.ListResourceData = c
-	resp.ActionData = c
+	resp.ActionData = &actionData{
+		client:      c,
+		maintenance: newMaintenanceSchedule(windows, wait),
+		dryRun:      data.DryRun.ValueBool(),
+		notes:       notes,
+	}
 	resp.EphemeralResourceData = c
 }
 
@@ -105,13 +246,19 @@ func (p *IruProvider) Resources(ctx context.Context) []func() resource.Resource
 		NewBlueprintLibraryItemResource,
 		NewADEIntegrationResource,
 		NewADEDeviceResource,
+		NewADEDeviceAssignmentsResource,
+		NewADEAssignmentPolicyResource,
 		NewDeviceResource,
 		NewDeviceNoteResource,
+		NewDeviceTagsResource,
+		NewDeviceTagAttachmentResource,
+		NewDeviceLostModeResource,
 		NewTagResource,
 		NewCustomScriptResource,
 		NewCustomProfileResource,
 		NewCustomAppResource,
 		NewInHouseAppResource,
+		NewSelfServiceCategoryResource,
 		NewPrismExportResource,
 	}
 }
@@ -128,6 +275,7 @@ func (p *IruProvider) DataSources(ctx context.Context) []func() datasource.DataS
 		NewDeviceLostModeDataSource,
 		NewADEDevicesDataSource,
 		NewADEDeviceDataSource,
+		NewDeviceEnrollmentDataSource,
 		NewADEIntegrationDevicesDataSource,
 		NewBlueprintsDataSource,
 		NewBlueprintDataSource,
@@ -136,8 +284,13 @@ func (p *IruProvider) DataSources(ctx context.Context) []func() datasource.DataS
 		NewBlueprintRoutingDataSource,
 		NewBlueprintRoutingActivityDataSource,
 		NewTagsDataSource,
+		NewTagDataSource,
+		NewTagDevicesDataSource,
+		NewLibraryItemsDataSource,
 		NewCustomScriptsDataSource,
+		NewCustomScriptDataSource,
 		NewCustomProfilesDataSource,
+		NewCustomProfileDataSource,
 		NewLibraryItemActivityDataSource,
 		NewLibraryItemStatusDataSource,
 		NewUsersDataSource,
@@ -145,6 +298,8 @@ func (p *IruProvider) DataSources(ctx context.Context) []func() datasource.DataS
 		NewDeviceActivityDataSource,
 		NewDeviceNotesDataSource,
 		NewDeviceCommandsDataSource,
+		NewPrismDataSource,
+		NewPrismAssertionDataSource,
 		NewPrismCountDataSource,
 		NewPrismFileVaultDataSource,
 		NewPrismAppFirewallDataSource,
@@ -153,9 +308,13 @@ func (p *IruProvider) DataSources(ctx context.Context) []func() datasource.DataS
 		NewVulnerabilityDetectionsDataSource,
 		NewVulnerabilityDevicesDataSource,
 		NewVulnerabilitySoftwareDataSource,
+		NewVulnerabilitySummaryDataSource,
 		NewAuditEventsDataSource,
 		NewLicensingDataSource,
 		NewPrismAppsDataSource,
+		NewAppInventoryDataSource,
+		NewTCCPolicyReportDataSource,
+		NewLocalAdminReportDataSource,
 		NewPrismCertificatesDataSource,
 		NewPrismLocalUsersDataSource,
 		NewPrismSystemExtensionsDataSource,
@@ -176,7 +335,7 @@ func (p *IruProvider) DataSources(ctx context.Context) []func() datasource.DataS
 }
 
 func (p *IruProvider) Actions(ctx context.Context) []func() action.Action {
-	return []func() action.Action{
+	actions := []func() action.Action{
 		NewDeviceRestartAction,
 		NewDeviceShutdownAction,
 		NewDeviceLockAction,
@@ -202,6 +361,11 @@ func (p *IruProvider) Actions(ctx context.Context) []func() action.Action {
 		NewDeviceRefreshCellularPlansAction,
 		NewDeviceRenewMDMProfileAction,
 	}
+
+	for i, newAction := range actions {
+		actions[i] = withActionHooks(newAction)
+	}
+	return actions
 }
 
 func (p *IruProvider) Functions(ctx context.Context) []func() function.Function {
@@ -229,6 +393,10 @@ func (p *IruProvider) ListResources(ctx context.Context) []func() list.ListResou
 		NewCustomScriptListResource,
 		NewInHouseAppListResource,
 		NewADEIntegrationListResource,
+		NewDeviceNoteListResource,
+		NewBlueprintLibraryItemListResource,
+		NewUserListResource,
+		NewSelfServiceCategoryListResource,
 	}
 }
 
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
@@ -51,3 +52,24 @@ func TestNormalizeAPIURL(t *testing.T) {
 		})
 	}
 }
+
+func TestProviderSchemas(t *testing.T) {
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	resp, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for _, d := range resp.Diagnostics {
+		t.Errorf("Unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+
+	for _, name := range []string{"iru_device_note", "iru_blueprint_library_item", "iru_user", "iru_self_service_category"} {
+		if _, ok := resp.ListResourceSchemas[name]; !ok {
+			t.Errorf("Expected a list resource schema for %s", name)
+		}
+	}
+}
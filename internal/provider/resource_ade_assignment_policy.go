@@ -0,0 +1,446 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = &adeAssignmentPolicyResource{}
+var _ resource.ResourceWithModifyPlan = &adeAssignmentPolicyResource{}
+
+func NewADEAssignmentPolicyResource() resource.Resource {
+	return &adeAssignmentPolicyResource{}
+}
+
+type adeAssignmentPolicyResource struct {
+	client *client.Client
+}
+
+type adeAssignmentPolicyResourceModel struct {
+	ID               types.String             `tfsdk:"id"`
+	Rules            []adeAssignmentRuleModel `tfsdk:"rules"`
+	MaxConcurrency   types.Int64              `tfsdk:"max_concurrency"`
+	DeviceBlueprints types.Map                `tfsdk:"device_blueprints"`
+}
+
+type adeAssignmentRuleModel struct {
+	BlueprintID  types.String `tfsdk:"blueprint_id"`
+	MatchType    types.String `tfsdk:"match_type"`
+	Model        types.String `tfsdk:"model"`
+	DeviceFamily types.String `tfsdk:"device_family"`
+	OS           types.String `tfsdk:"os"`
+	DEPAccount   types.String `tfsdk:"dep_account"`
+	Color        types.String `tfsdk:"color"`
+	Description  types.String `tfsdk:"description"`
+}
+
+// adeAssignmentRule is a compiled rule. Every matcher must match for the rule
+// to apply.
+type adeAssignmentRule struct {
+	blueprintID string
+	matchers    []adeFieldMatcher
+}
+
+type adeFieldMatcher struct {
+	field func(client.ADEDevice) string
+	match func(string) bool
+}
+
+func (r *adeAssignmentPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ade_assignment_policy"
+}
+
+func (r *adeAssignmentPolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	ruleField := func(name string) schema.StringAttribute {
+		return schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: fmt.Sprintf("A pattern the %s of the Device must match.", name),
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Assigns unenrolled Automated Device Enrollment (ADE) devices to blueprints using an ordered list of rules. On each apply every unenrolled ADE device is checked against the rules, and the first matching rule sets the blueprint of the device. Devices that match no rule, and devices that have already enrolled, are left alone. `device_blueprints` shows the devices that a plan would move. Destroying this resource removes it from state without changing any device.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The identifier of the assignment policy.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rules": schema.ListNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "The rules to evaluate, in order. A rule matches a device when every pattern set on the rule matches. A rule without patterns matches every device.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"blueprint_id": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "The ID of the blueprint to assign matching devices to.",
+						},
+						"match_type": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "How the patterns of this rule are interpreted: `glob` (the default), where `*` and `?` are wildcards and the whole value must match, or `regex`, where the regular expression may match any part of the value.",
+						},
+						"model":         ruleField("model"),
+						"device_family": ruleField("device family"),
+						"os":            ruleField("OS"),
+						"dep_account":   ruleField("DEP account"),
+						"color":         ruleField("color"),
+						"description":   ruleField("description"),
+					},
+				},
+			},
+			"max_concurrency": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("The maximum number of devices updated at the same time. Defaults to `%d`.", defaultConcurrency),
+			},
+			"device_blueprints": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The blueprint of each unenrolled ADE device matched by the rules, keyed by serial number. In a plan, changed entries are the devices that will move to a new blueprint.",
+			},
+		},
+	}
+}
+
+func (r *adeAssignmentPolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *adeAssignmentPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan adeAssignmentPolicyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Rules that depend on values known only after apply are evaluated then.
+	if !adeAssignmentRulesKnown(plan.Rules) || r.client == nil {
+		return
+	}
+
+	rules, diags := compileADEAssignmentRules(plan.Rules)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	devices, err := listADEDevices(ctx, r.client, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read ADE devices, got error: %s", err))
+		return
+	}
+
+	desired, diags := types.MapValueFrom(ctx, types.StringType, adeAssignmentTargets(rules, devices))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("device_blueprints"), desired)...)
+}
+
+func (r *adeAssignmentPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data adeAssignmentPolicyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue("ade_assignment_policy")
+	resp.Diagnostics.Append(r.apply(ctx, &data)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *adeAssignmentPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data adeAssignmentPolicyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rules, diags := compileADEAssignmentRules(data.Rules)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	devices, err := listADEDevices(ctx, r.client, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read ADE devices, got error: %s", err))
+		return
+	}
+
+	current := map[string]string{}
+	for _, device := range devices {
+		if device.IsEnrolled {
+			continue
+		}
+		if _, ok := matchADEAssignmentRule(rules, device); ok {
+			current[device.SerialNumber] = device.BlueprintID
+		}
+	}
+
+	data.DeviceBlueprints, diags = types.MapValueFrom(ctx, types.StringType, current)
+	resp.Diagnostics.Append(diags...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *adeAssignmentPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data adeAssignmentPolicyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *adeAssignmentPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Devices keep the blueprints they were assigned. We just remove the
+	// resource from Terraform state.
+}
+
+// apply moves devices to the blueprints in the planned device_blueprints, or
+// evaluates the rules again when the plan could not. data is updated with the
+// resulting blueprints.
+func (r *adeAssignmentPolicyResource) apply(ctx context.Context, data *adeAssignmentPolicyResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	rules, ruleDiags := compileADEAssignmentRules(data.Rules)
+	diags.Append(ruleDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	devices, err := listADEDevices(ctx, r.client, nil)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read ADE devices, got error: %s", err))
+		return diags
+	}
+
+	targets := map[string]string{}
+	if data.DeviceBlueprints.IsUnknown() || data.DeviceBlueprints.IsNull() {
+		targets = adeAssignmentTargets(rules, devices)
+	} else {
+		diags.Append(data.DeviceBlueprints.ElementsAs(ctx, &targets, false)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	type move struct {
+		serial string
+		device client.ADEDevice
+		err    error
+	}
+	var moves []*move
+	result := map[string]string{}
+	for _, device := range devices {
+		target, ok := targets[device.SerialNumber]
+		if !ok || device.IsEnrolled {
+			continue
+		}
+		if device.BlueprintID == target {
+			result[device.SerialNumber] = target
+			continue
+		}
+		moves = append(moves, &move{serial: device.SerialNumber, device: device})
+	}
+
+	workers := defaultConcurrency
+	if !data.MaxConcurrency.IsNull() {
+		workers = int(data.MaxConcurrency.ValueInt64())
+	}
+
+	forEachConcurrently(workers, len(moves), func(i int) {
+		m := moves[i]
+		request := map[string]interface{}{"blueprint_id": targets[m.serial]}
+		var deviceResponse client.ADEDevice
+		m.err = r.client.DoRequest(ctx, "PATCH", "/api/v1/integrations/apple/ade/devices/"+m.device.ID, request, &deviceResponse)
+		if m.err == nil {
+			m.device.BlueprintID = targets[m.serial]
+		}
+	})
+
+	for _, m := range moves {
+		if m.err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to move ADE device %s to blueprint %s, got error: %s", m.serial, targets[m.serial], m.err))
+		}
+		result[m.serial] = m.device.BlueprintID
+	}
+
+	// Devices that disappeared or enrolled since the plan keep their planned
+	// value, and drop out on the next refresh.
+	var gone []string
+	for serial, target := range targets {
+		if _, ok := result[serial]; !ok {
+			result[serial] = target
+			gone = append(gone, serial)
+		}
+	}
+	if len(gone) > 0 {
+		sort.Strings(gone)
+		diags.AddWarning("ADE Devices Not Found", fmt.Sprintf("The following devices were no longer unenrolled ADE devices and were not moved: %s", strings.Join(gone, ", ")))
+	}
+
+	var mapDiags diag.Diagnostics
+	data.DeviceBlueprints, mapDiags = types.MapValueFrom(ctx, types.StringType, result)
+	diags.Append(mapDiags...)
+
+	return diags
+}
+
+// adeAssignmentTargets returns the blueprint each unenrolled device should be
+// assigned to, keyed by serial number. Devices that match no rule are left
+// out.
+func adeAssignmentTargets(rules []adeAssignmentRule, devices []client.ADEDevice) map[string]string {
+	targets := map[string]string{}
+	for _, device := range devices {
+		if device.IsEnrolled {
+			continue
+		}
+		if blueprintID, ok := matchADEAssignmentRule(rules, device); ok {
+			targets[device.SerialNumber] = blueprintID
+		}
+	}
+	return targets
+}
+
+// matchADEAssignmentRule returns the blueprint of the first rule that matches
+// device.
+func matchADEAssignmentRule(rules []adeAssignmentRule, device client.ADEDevice) (string, bool) {
+	for _, rule := range rules {
+		matched := true
+		for _, m := range rule.matchers {
+			if !m.match(m.field(device)) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return rule.blueprintID, true
+		}
+	}
+	return "", false
+}
+
+func adeAssignmentRulesKnown(rules []adeAssignmentRuleModel) bool {
+	for _, rule := range rules {
+		for _, value := range []types.String{rule.BlueprintID, rule.MatchType, rule.Model, rule.DeviceFamily, rule.OS, rule.DEPAccount, rule.Color, rule.Description} {
+			if value.IsUnknown() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// compileADEAssignmentRules validates the configured rules and compiles their
+// patterns.
+func compileADEAssignmentRules(models []adeAssignmentRuleModel) ([]adeAssignmentRule, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	rules := make([]adeAssignmentRule, 0, len(models))
+	for i, model := range models {
+		matchType := strings.ToLower(model.MatchType.ValueString())
+		if matchType == "" {
+			matchType = "glob"
+		}
+		if matchType != "glob" && matchType != "regex" {
+			diags.AddAttributeError(path.Root("rules").AtListIndex(i).AtName("match_type"), "Invalid Match Type", fmt.Sprintf("`match_type` must be `glob` or `regex`, got %q.", model.MatchType.ValueString()))
+			continue
+		}
+
+		rule := adeAssignmentRule{blueprintID: model.BlueprintID.ValueString()}
+		fields := []struct {
+			name    string
+			pattern types.String
+			field   func(client.ADEDevice) string
+		}{
+			{"model", model.Model, func(d client.ADEDevice) string { return d.Model }},
+			{"device_family", model.DeviceFamily, func(d client.ADEDevice) string { return d.DeviceFamily }},
+			{"os", model.OS, func(d client.ADEDevice) string { return d.OS }},
+			{"dep_account", model.DEPAccount, func(d client.ADEDevice) string { return d.DEPAccount }},
+			{"color", model.Color, func(d client.ADEDevice) string { return d.Color }},
+			{"description", model.Description, func(d client.ADEDevice) string { return d.Description }},
+		}
+		for _, f := range fields {
+			if f.pattern.IsNull() {
+				continue
+			}
+			match, err := compileADEPattern(matchType, f.pattern.ValueString())
+			if err != nil {
+				diags.AddAttributeError(path.Root("rules").AtListIndex(i).AtName(f.name), "Invalid Pattern", fmt.Sprintf("Unable to compile %s pattern %q, got error: %s", matchType, f.pattern.ValueString(), err))
+				continue
+			}
+			rule.matchers = append(rule.matchers, adeFieldMatcher{field: f.field, match: match})
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, diags
+}
+
+// compileADEPattern compiles a pattern of the given match type. Globs are
+// converted to anchored regular expressions where `*` matches any run of
+// characters, `/` included, and `?` matches one character; everything else is
+// literal.
+func compileADEPattern(matchType, pattern string) (func(string) bool, error) {
+	if matchType != "regex" {
+		var expr strings.Builder
+		expr.WriteString("(?s)^")
+		for _, r := range pattern {
+			switch r {
+			case '*':
+				expr.WriteString(".*")
+			case '?':
+				expr.WriteString(".")
+			default:
+				expr.WriteString(regexp.QuoteMeta(string(r)))
+			}
+		}
+		expr.WriteString("$")
+		pattern = expr.String()
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return re.MatchString, nil
+}
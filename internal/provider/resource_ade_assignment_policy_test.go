@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestADEAssignmentTargets(t *testing.T) {
+	rule := func(blueprintID, matchType string) adeAssignmentRuleModel {
+		return adeAssignmentRuleModel{
+			BlueprintID:  types.StringValue(blueprintID),
+			MatchType:    types.StringValue(matchType),
+			Model:        types.StringNull(),
+			DeviceFamily: types.StringNull(),
+			OS:           types.StringNull(),
+			DEPAccount:   types.StringNull(),
+			Color:        types.StringNull(),
+			Description:  types.StringNull(),
+		}
+	}
+
+	engineering := rule("bp-engineering", "glob")
+	engineering.Model = types.StringValue("MacBook Pro*")
+	engineering.DEPAccount = types.StringValue("Engineering")
+
+	kiosk := rule("bp-kiosk", "regex")
+	kiosk.DeviceFamily = types.StringValue("^iPad$")
+
+	fallback := rule("bp-default", "")
+	fallback.OS = types.StringValue("OSX")
+
+	rules, diags := compileADEAssignmentRules([]adeAssignmentRuleModel{engineering, kiosk, fallback})
+	if diags.HasError() {
+		t.Fatalf("Expected no errors, got %v", diags)
+	}
+
+	devices := []client.ADEDevice{
+		{SerialNumber: "MBP1", Model: "MacBook Pro (14-inch)", DEPAccount: "Engineering", OS: "OSX"},
+		{SerialNumber: "MBP2", Model: "MacBook Pro (14-inch)", DEPAccount: "Sales", OS: "OSX"},
+		{SerialNumber: "IPAD", DeviceFamily: "iPad", OS: "iOS"},
+		{SerialNumber: "IPHONE", DeviceFamily: "iPhone", OS: "iOS"},
+		{SerialNumber: "ENROLLED", Model: "MacBook Pro (14-inch)", DEPAccount: "Engineering", IsEnrolled: true},
+	}
+
+	targets := adeAssignmentTargets(rules, devices)
+	expected := map[string]string{
+		"MBP1": "bp-engineering",
+		"MBP2": "bp-default",
+		"IPAD": "bp-kiosk",
+	}
+	if len(targets) != len(expected) {
+		t.Fatalf("Expected targets %v, got %v", expected, targets)
+	}
+	for serial, blueprintID := range expected {
+		if targets[serial] != blueprintID {
+			t.Errorf("Expected %s to target %s, got %s", serial, blueprintID, targets[serial])
+		}
+	}
+}
+
+func TestCompileADEAssignmentRulesInvalid(t *testing.T) {
+	bad := adeAssignmentRuleModel{
+		BlueprintID:  types.StringValue("bp"),
+		MatchType:    types.StringValue("regex"),
+		Model:        types.StringValue("MacBook ("),
+		DeviceFamily: types.StringNull(),
+		OS:           types.StringNull(),
+		DEPAccount:   types.StringNull(),
+		Color:        types.StringNull(),
+		Description:  types.StringNull(),
+	}
+	if _, diags := compileADEAssignmentRules([]adeAssignmentRuleModel{bad}); !diags.HasError() {
+		t.Error("Expected an invalid regex to be rejected")
+	}
+
+	bad.MatchType = types.StringValue("fuzzy")
+	if _, diags := compileADEAssignmentRules([]adeAssignmentRuleModel{bad}); !diags.HasError() {
+		t.Error("Expected an unknown match_type to be rejected")
+	}
+}
+
+func TestCompileADEPatternGlob(t *testing.T) {
+	cases := []struct {
+		pattern, value string
+		expected       bool
+	}{
+		{"IPAD*", "IPAD WI-FI/CELL", true},
+		{"*/CELL", "IPAD WI-FI/CELL", true},
+		{"MacBook Pro (1?-inch)", "MacBook Pro (14-inch)", true},
+		{"MacBook Pro (1?-inch)", "MacBook Pro (14-inch, M3)", false},
+		{`C:\Devices\*`, `C:\Devices\Lab`, true},
+		{"[abc]", "a", false},
+		{"[abc]", "[abc]", true},
+		{"Mac", "MacBook", false},
+	}
+	for _, tc := range cases {
+		match, err := compileADEPattern("glob", tc.pattern)
+		if err != nil {
+			t.Fatalf("Unexpected error compiling %q: %s", tc.pattern, err)
+		}
+		if got := match(tc.value); got != tc.expected {
+			t.Errorf("Expected %q matching %q to be %t, got %t", tc.pattern, tc.value, tc.expected, got)
+		}
+	}
+}
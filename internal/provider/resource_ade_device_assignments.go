@@ -0,0 +1,370 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = &adeDeviceAssignmentsResource{}
+
+func NewADEDeviceAssignmentsResource() resource.Resource {
+	return &adeDeviceAssignmentsResource{}
+}
+
+type adeDeviceAssignmentsResource struct {
+	client *client.Client
+}
+
+type adeDeviceAssignmentsResourceModel struct {
+	ID             types.String                        `tfsdk:"id"`
+	Assignments    map[string]adeDeviceAssignmentModel `tfsdk:"assignments"`
+	MaxConcurrency types.Int64                         `tfsdk:"max_concurrency"`
+	MissingSerials types.List                          `tfsdk:"missing_serials"`
+}
+
+type adeDeviceAssignmentModel struct {
+	DeviceID            types.String `tfsdk:"device_id"`
+	BlueprintID         types.String `tfsdk:"blueprint_id"`
+	UserID              types.String `tfsdk:"user_id"`
+	AssetTag            types.String `tfsdk:"asset_tag"`
+	UseBlueprintRouting types.Bool   `tfsdk:"use_blueprint_routing"`
+}
+
+func (r *adeDeviceAssignmentsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ade_device_assignments"
+}
+
+func (r *adeDeviceAssignmentsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the assignments of many Automated Device Enrollment (ADE) devices by serial number. Serial numbers are resolved against the ADE devices of every integration, and only the attributes that differ from the current assignment are updated. Attributes left unset on an assignment are not managed. Serial numbers that are not found are reported in `missing_serials` and are assigned once they appear. Destroying this resource removes it from state without changing any device.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The identifier of this set of assignments.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"assignments": schema.MapNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "The assignments to apply, keyed by device serial number.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"device_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The unique identifier for the ADE Device with this serial number, or null when it was not found.",
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
+						"blueprint_id": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "The ID of the blueprint to assign to the Device.",
+						},
+						"user_id": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "The ID of the user to assign to the Device.",
+						},
+						"asset_tag": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "The asset tag to assign to the Device.",
+						},
+						"use_blueprint_routing": schema.BoolAttribute{
+							Optional:            true,
+							MarkdownDescription: "Whether the Device uses Blueprint Routing.",
+						},
+					},
+				},
+			},
+			"max_concurrency": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("The maximum number of devices updated at the same time. Defaults to `%d`.", defaultConcurrency),
+			},
+			"missing_serials": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Serial numbers that are not in any ADE integration.",
+			},
+		},
+	}
+}
+
+func (r *adeDeviceAssignmentsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *adeDeviceAssignmentsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data adeDeviceAssignmentsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue("ade_device_assignments")
+	resp.Diagnostics.Append(r.apply(ctx, &data)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *adeDeviceAssignmentsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data adeDeviceAssignmentsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	devices, err := listADEDevices(ctx, r.client, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read ADE devices, got error: %s", err))
+		return
+	}
+	bySerial := adeDevicesBySerial(devices)
+
+	for serial, assignment := range data.Assignments {
+		device, ok := bySerial[strings.ToUpper(serial)]
+		if !ok {
+			assignment.DeviceID = types.StringNull()
+		} else {
+			assignment = adeDeviceAssignmentFromDevice(assignment, device)
+		}
+		data.Assignments[serial] = assignment
+	}
+
+	var diags diag.Diagnostics
+	data.MissingSerials, diags = missingADESerials(ctx, data.Assignments)
+	resp.Diagnostics.Append(diags...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *adeDeviceAssignmentsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data adeDeviceAssignmentsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *adeDeviceAssignmentsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// ADE device assignments are left as they are. We just remove the
+	// resource from Terraform state.
+}
+
+// apply resolves every serial number in data and updates the devices whose
+// assignment differs. data is updated with the resulting device values, so a
+// failed update shows up as a difference on the next plan.
+func (r *adeDeviceAssignmentsResource) apply(ctx context.Context, data *adeDeviceAssignmentsResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	devices, err := listADEDevices(ctx, r.client, nil)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read ADE devices, got error: %s", err))
+		return diags
+	}
+	bySerial := adeDevicesBySerial(devices)
+
+	type update struct {
+		serial  string
+		device  client.ADEDevice
+		request map[string]interface{}
+		err     error
+	}
+	var updates []*update
+
+	serials := make([]string, 0, len(data.Assignments))
+	for serial := range data.Assignments {
+		serials = append(serials, serial)
+	}
+	sort.Strings(serials)
+
+	for _, serial := range serials {
+		assignment := data.Assignments[serial]
+		device, ok := bySerial[strings.ToUpper(serial)]
+		if !ok {
+			assignment.DeviceID = types.StringNull()
+			data.Assignments[serial] = assignment
+			continue
+		}
+
+		request := adeDeviceAssignmentChanges(assignment, device)
+		if len(request) == 0 {
+			data.Assignments[serial] = adeDeviceAssignmentFromDevice(assignment, device)
+			continue
+		}
+		updates = append(updates, &update{serial: serial, device: device, request: request})
+	}
+
+	workers := defaultConcurrency
+	if !data.MaxConcurrency.IsNull() {
+		workers = int(data.MaxConcurrency.ValueInt64())
+	}
+
+	forEachConcurrently(workers, len(updates), func(i int) {
+		u := updates[i]
+		var deviceResponse client.ADEDevice
+		u.err = r.client.DoRequest(ctx, "PATCH", "/api/v1/integrations/apple/ade/devices/"+u.device.ID, u.request, &deviceResponse)
+		if u.err == nil {
+			u.device = deviceResponse
+		}
+	})
+
+	for _, u := range updates {
+		if u.err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to update ADE device %s, got error: %s", u.serial, u.err))
+		}
+		data.Assignments[u.serial] = adeDeviceAssignmentFromDevice(data.Assignments[u.serial], u.device)
+	}
+
+	var missingDiags diag.Diagnostics
+	data.MissingSerials, missingDiags = missingADESerials(ctx, data.Assignments)
+	diags.Append(missingDiags...)
+
+	if len(data.MissingSerials.Elements()) > 0 {
+		diags.AddWarning(
+			"ADE Devices Not Found",
+			fmt.Sprintf("The following serial numbers are not in any ADE integration and were not assigned: %s", strings.Join(adeMissingSerialList(data.Assignments), ", ")),
+		)
+	}
+
+	return diags
+}
+
+// adeDeviceAssignmentChanges returns the PATCH body for the configured
+// attributes of assignment that differ from device.
+func adeDeviceAssignmentChanges(assignment adeDeviceAssignmentModel, device client.ADEDevice) map[string]interface{} {
+	request := map[string]interface{}{}
+	if !assignment.BlueprintID.IsNull() && assignment.BlueprintID.ValueString() != device.BlueprintID {
+		request["blueprint_id"] = assignment.BlueprintID.ValueString()
+	}
+	if !assignment.UserID.IsNull() && assignment.UserID.ValueString() != device.UserID {
+		request["user_id"] = assignment.UserID.ValueString()
+	}
+	if !assignment.AssetTag.IsNull() && assignment.AssetTag.ValueString() != device.AssetTag {
+		request["asset_tag"] = assignment.AssetTag.ValueString()
+	}
+	if !assignment.UseBlueprintRouting.IsNull() && assignment.UseBlueprintRouting.ValueBool() != device.UseBlueprintRouting {
+		request["use_blueprint_routing"] = assignment.UseBlueprintRouting.ValueBool()
+	}
+	return request
+}
+
+// adeDeviceAssignmentFromDevice returns assignment with its configured
+// attributes replaced by the values of device. Unset attributes stay null.
+func adeDeviceAssignmentFromDevice(assignment adeDeviceAssignmentModel, device client.ADEDevice) adeDeviceAssignmentModel {
+	assignment.DeviceID = types.StringValue(device.ID)
+	if !assignment.BlueprintID.IsNull() {
+		assignment.BlueprintID = types.StringValue(device.BlueprintID)
+	}
+	if !assignment.UserID.IsNull() {
+		assignment.UserID = types.StringValue(device.UserID)
+	}
+	if !assignment.AssetTag.IsNull() {
+		assignment.AssetTag = types.StringValue(device.AssetTag)
+	}
+	if !assignment.UseBlueprintRouting.IsNull() {
+		assignment.UseBlueprintRouting = types.BoolValue(device.UseBlueprintRouting)
+	}
+	return assignment
+}
+
+func adeMissingSerialList(assignments map[string]adeDeviceAssignmentModel) []string {
+	missing := []string{}
+	for serial, assignment := range assignments {
+		if assignment.DeviceID.IsNull() {
+			missing = append(missing, serial)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+func missingADESerials(ctx context.Context, assignments map[string]adeDeviceAssignmentModel) (types.List, diag.Diagnostics) {
+	return types.ListValueFrom(ctx, types.StringType, adeMissingSerialList(assignments))
+}
+
+// adeDevicesBySerial indexes devices by upper-case serial number.
+func adeDevicesBySerial(devices []client.ADEDevice) map[string]client.ADEDevice {
+	bySerial := make(map[string]client.ADEDevice, len(devices))
+	for _, device := range devices {
+		bySerial[strings.ToUpper(device.SerialNumber)] = device
+	}
+	return bySerial
+}
+
+// listADEDevices returns every ADE device matching params, following the
+// pages of the ADE devices list.
+func listADEDevices(ctx context.Context, c *client.Client, params url.Values) ([]client.ADEDevice, error) {
+	var allDevices []client.ADEDevice
+	for device, err := range adeDevicePages(ctx, c, params) {
+		if err != nil {
+			return nil, err
+		}
+		allDevices = append(allDevices, device)
+	}
+	return allDevices, nil
+}
+
+// adeDevicePages iterates over the ADE devices matching params, requesting the
+// next page only once the previous one has been consumed.
+func adeDevicePages(ctx context.Context, c *client.Client, params url.Values) iter.Seq2[client.ADEDevice, error] {
+	return func(yield func(client.ADEDevice, error) bool) {
+		page := 1
+		for {
+			query := url.Values{}
+			for key, values := range params {
+				query[key] = values
+			}
+			query.Set("page", fmt.Sprintf("%d", page))
+
+			var listResp struct {
+				Results []client.ADEDevice `json:"results"`
+				Next    string             `json:"next"`
+			}
+			err := c.DoRequest(ctx, "GET", "/api/v1/integrations/apple/ade/devices?"+query.Encode(), nil, &listResp)
+			if err != nil {
+				yield(client.ADEDevice{}, err)
+				return
+			}
+
+			for _, device := range listResp.Results {
+				if !yield(device, nil) {
+					return
+				}
+			}
+			if listResp.Next == "" || len(listResp.Results) == 0 {
+				return
+			}
+			page++
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestADEDeviceAssignmentsApply(t *testing.T) {
+	var mu sync.Mutex
+	patches := map[string]map[string]interface{}{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/integrations/apple/ade/devices":
+			if r.URL.Query().Get("page") == "1" {
+				_, _ = w.Write([]byte(`{"next": "page2", "results": [
+					{"device_id": "ade-1", "serial_number": "C02AAA", "blueprint_id": "bp-old", "asset_tag": "A-1"},
+					{"device_id": "ade-2", "serial_number": "C02BBB", "blueprint_id": "bp-new", "asset_tag": "B-1"}
+				]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"next": "", "results": [
+				{"device_id": "ade-3", "serial_number": "c02ccc", "blueprint_id": "bp-old", "user_id": "u-1"}
+			]}`))
+		case r.Method == http.MethodPatch:
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			id := r.URL.Path[len("/api/v1/integrations/apple/ade/devices/"):]
+			mu.Lock()
+			patches[id] = body
+			mu.Unlock()
+
+			device := client.ADEDevice{ID: id, BlueprintID: body["blueprint_id"].(string)}
+			_ = json.NewEncoder(w).Encode(device)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer server.Close()
+
+	r := &adeDeviceAssignmentsResource{client: client.NewClient(server.URL, "test-token")}
+	data := adeDeviceAssignmentsResourceModel{
+		MaxConcurrency: types.Int64Value(2),
+		Assignments: map[string]adeDeviceAssignmentModel{
+			"C02AAA":  {BlueprintID: types.StringValue("bp-new"), UserID: types.StringNull(), AssetTag: types.StringNull(), UseBlueprintRouting: types.BoolNull()},
+			"C02BBB":  {BlueprintID: types.StringValue("bp-new"), UserID: types.StringNull(), AssetTag: types.StringValue("B-1"), UseBlueprintRouting: types.BoolNull()},
+			"C02CCC":  {BlueprintID: types.StringValue("bp-new"), UserID: types.StringNull(), AssetTag: types.StringNull(), UseBlueprintRouting: types.BoolNull()},
+			"C02MISS": {BlueprintID: types.StringValue("bp-new"), UserID: types.StringNull(), AssetTag: types.StringNull(), UseBlueprintRouting: types.BoolNull()},
+		},
+	}
+
+	diags := r.apply(context.Background(), &data)
+	if diags.HasError() {
+		t.Fatalf("Expected no errors, got %v", diags)
+	}
+	if diags.WarningsCount() != 1 {
+		t.Errorf("Expected a warning about the missing serial, got %v", diags)
+	}
+
+	if len(patches) != 2 || patches["ade-1"]["blueprint_id"] != "bp-new" || patches["ade-3"]["blueprint_id"] != "bp-new" {
+		t.Errorf("Expected only ade-1 and ade-3 to be patched, got %v", patches)
+	}
+	if len(patches["ade-1"]) != 1 {
+		t.Errorf("Expected only blueprint_id to be patched, got %v", patches["ade-1"])
+	}
+
+	if got := data.Assignments["C02CCC"].DeviceID.ValueString(); got != "ade-3" {
+		t.Errorf("Expected C02CCC to resolve to ade-3, got %s", got)
+	}
+	if !data.Assignments["C02MISS"].DeviceID.IsNull() {
+		t.Errorf("Expected C02MISS to have no device ID")
+	}
+	missing := data.MissingSerials.Elements()
+	if len(missing) != 1 || missing[0].(types.String).ValueString() != "C02MISS" {
+		t.Errorf("Expected missing_serials to be [C02MISS], got %v", missing)
+	}
+}
@@ -3,9 +3,15 @@ package provider
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
@@ -18,6 +24,11 @@ import (
 var _ resource.Resource = &adeIntegrationResource{}
 var _ resource.ResourceWithImportState = &adeIntegrationResource{}
 var _ resource.ResourceWithIdentity = &adeIntegrationResource{}
+var _ resource.ResourceWithModifyPlan = &adeIntegrationResource{}
+
+// defaultADETokenWarningDays is the number of days before the MDM server token
+// expires at which plans start warning about it.
+const defaultADETokenWarningDays = 30
 
 func NewADEIntegrationResource() resource.Resource {
 	return &adeIntegrationResource{}
@@ -33,6 +44,10 @@ type adeIntegrationResourceModel struct {
 	Phone               types.String `tfsdk:"phone"`
 	Email               types.String `tfsdk:"email"`
 	MDMServerTokenFile  types.String `tfsdk:"mdm_server_token_file"`
+	MDMServerTokenWO    types.String `tfsdk:"mdm_server_token_wo"`
+	MDMServerTokenPath  types.String `tfsdk:"mdm_server_token_path"`
+	MDMServerTokenHash  types.String `tfsdk:"mdm_server_token_sha256"`
+	ExpiryWarningDays   types.Int64  `tfsdk:"expiry_warning_days"`
 	AccessTokenExpiry   types.String `tfsdk:"access_token_expiry"`
 	ServerName          types.String `tfsdk:"server_name"`
 	ServerUUID          types.String `tfsdk:"server_uuid"`
@@ -54,7 +69,7 @@ func (r *adeIntegrationResource) Metadata(ctx context.Context, req resource.Meta
 
 func (r *adeIntegrationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Manages an Automated Device Enrollment (ADE) integration with Apple Business Manager. This resource handles the MDM server token (.p7m) and enrollment settings.",
+		MarkdownDescription: "Manages an Automated Device Enrollment (ADE) integration with Apple Business Manager. This resource handles the MDM server token (.p7m) and enrollment settings.\n\nProvide the token with exactly one of `mdm_server_token_wo`, `mdm_server_token_path` or the deprecated `mdm_server_token_file`. Only a SHA-256 fingerprint of the token is kept in state, and a changed fingerprint renews the integration with the new token. An imported integration has no fingerprint, so the first apply only records the configured token's fingerprint and the integration is not renewed until the token changes after that. Plans warn when the token is close to expiring.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -76,9 +91,28 @@ func (r *adeIntegrationResource) Schema(ctx context.Context, req resource.Schema
 				MarkdownDescription: "A support email address for the integration (shown to users during enrollment).",
 			},
 			"mdm_server_token_file": schema.StringAttribute{
-				Required:            true,
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The content of the MDM server token file (.p7m) downloaded from Apple Business Manager, for example from `file()`. Unlike `mdm_server_token_wo`, the value is uploaded as is rather than base64-decoded. This value is stored in state.",
+				DeprecationMessage:  "Use mdm_server_token_wo or mdm_server_token_path instead, which keep the token out of state.",
+			},
+			"mdm_server_token_wo": schema.StringAttribute{
+				Optional:            true,
 				Sensitive:           true,
-				MarkdownDescription: "The base64-encoded content of the MDM server token file (.p7m) downloaded from Apple Business Manager.",
+				WriteOnly:           true,
+				MarkdownDescription: "The base64-encoded content of the MDM server token file (.p7m), for example from `filebase64()`. The value is decoded before it is uploaded, so `filebase64()` of a token file and `file()` of the same file in `mdm_server_token_file` upload the same token. This value is never stored in state.",
+			},
+			"mdm_server_token_path": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The path to the MDM server token file (.p7m). The file is read during plan and apply, and its content is never stored in state.",
+			},
+			"mdm_server_token_sha256": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The SHA-256 fingerprint of the MDM server token. A change in fingerprint renews the integration with the new token.",
+			},
+			"expiry_warning_days": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Plans warn when `days_left` or `access_token_expiry` is fewer than this many days away. Defaults to `30`. Set to `0` to disable the warnings.",
 			},
 			"use_blueprint_routing": schema.BoolAttribute{
 				Optional:            true,
@@ -174,8 +208,19 @@ func (r *adeIntegrationResource) Create(ctx context.Context, req resource.Create
 		}
 	}
 
-	fileContent := []byte(data.MDMServerTokenFile.ValueString())
-	
+	var config adeIntegrationResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	fileContent, diags := adeServerToken(config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.MDMServerTokenHash = types.StringValue(adeTokenFingerprint(fileContent))
+
 	var adeResponse client.ADEIntegration
 	err := r.client.DoMultipartRequest(ctx, "POST", "/api/v1/integrations/apple/ade/", fields, "file", "token.p7m", bytes.NewReader(fileContent), &adeResponse)
 	if err != nil {
@@ -220,6 +265,7 @@ func (r *adeIntegrationResource) Read(ctx context.Context, req resource.ReadRequ
 	}
 
 	r.updateModelWithADEIntegration(&data, &adeResponse)
+	data.MDMServerTokenHash = adeStateTokenFingerprint(data)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 	resp.Diagnostics.Append(resp.Identity.Set(ctx, &identity)...)
@@ -234,7 +280,20 @@ func (r *adeIntegrationResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
-	if !plan.MDMServerTokenFile.Equal(state.MDMServerTokenFile) {
+	var config adeIntegrationResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	fileContent, diags := adeServerToken(config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.MDMServerTokenHash = types.StringValue(adeTokenFingerprint(fileContent))
+
+	if adeTokenChanged(plan.MDMServerTokenHash, state) {
 		// Token changed, use Renew endpoint
 		fields := map[string]string{
 			"phone": plan.Phone.ValueString(),
@@ -251,8 +310,6 @@ func (r *adeIntegrationResource) Update(ctx context.Context, req resource.Update
 			}
 		}
 
-		fileContent := []byte(plan.MDMServerTokenFile.ValueString())
-		
 		var adeResponse client.ADEIntegration
 		err := r.client.DoMultipartRequest(ctx, "POST", "/api/v1/integrations/apple/ade/"+plan.ID.ValueString()+"/renew", fields, "file", "token.p7m", bytes.NewReader(fileContent), &adeResponse)
 		if err != nil {
@@ -307,6 +364,54 @@ func (r *adeIntegrationResource) Delete(ctx context.Context, req resource.Delete
 	}
 }
 
+func (r *adeIntegrationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var config adeIntegrationResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	fingerprint := types.StringUnknown()
+	if adeServerTokenKnown(config) {
+		token, diags := adeServerToken(config)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		fingerprint = types.StringValue(adeTokenFingerprint(token))
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("mdm_server_token_sha256"), fingerprint)...)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state adeIntegrationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A new token is about to be uploaded, so the current expiry no longer
+	// applies.
+	if adeTokenChanged(fingerprint, state) {
+		return
+	}
+
+	threshold := int64(defaultADETokenWarningDays)
+	if !config.ExpiryWarningDays.IsNull() {
+		threshold = config.ExpiryWarningDays.ValueInt64()
+	}
+	for _, warning := range adeTokenExpiryWarnings(state.DaysLeft, state.AccessTokenExpiry.ValueString(), threshold, time.Now()) {
+		resp.Diagnostics.AddAttributeWarning(path.Root("mdm_server_token_sha256"), "ADE Server Token Expiring", warning)
+	}
+}
+
 func (r *adeIntegrationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
@@ -338,3 +443,97 @@ func (r *adeIntegrationResource) updateModelWithADEIntegration(data *adeIntegrat
 	data.Status = types.StringValue(adeResponse.Status)
 	data.UseBlueprintRouting = types.BoolValue(adeResponse.UseBlueprintRouting)
 }
+
+// adeServerTokenKnown reports whether the token can be read at plan time.
+func adeServerTokenKnown(config adeIntegrationResourceModel) bool {
+	return !config.MDMServerTokenFile.IsUnknown() && !config.MDMServerTokenWO.IsUnknown() && !config.MDMServerTokenPath.IsUnknown()
+}
+
+// adeServerToken returns the MDM server token from whichever of the token
+// attributes is configured.
+func adeServerToken(config adeIntegrationResourceModel) ([]byte, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	set := 0
+	for _, value := range []types.String{config.MDMServerTokenFile, config.MDMServerTokenWO, config.MDMServerTokenPath} {
+		if !value.IsNull() {
+			set++
+		}
+	}
+	if set != 1 {
+		diags.AddError("Invalid MDM Server Token", "Exactly one of `mdm_server_token_wo`, `mdm_server_token_path` or `mdm_server_token_file` must be set.")
+		return nil, diags
+	}
+
+	switch {
+	case !config.MDMServerTokenWO.IsNull():
+		token, err := base64.StdEncoding.DecodeString(config.MDMServerTokenWO.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("mdm_server_token_wo"), "Invalid MDM Server Token", fmt.Sprintf("Unable to decode the base64 token, got error: %s", err))
+			return nil, diags
+		}
+		return token, diags
+	case !config.MDMServerTokenPath.IsNull():
+		token, err := os.ReadFile(config.MDMServerTokenPath.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("mdm_server_token_path"), "Invalid MDM Server Token", fmt.Sprintf("Unable to read the token file, got error: %s", err))
+			return nil, diags
+		}
+		return token, diags
+	default:
+		return []byte(config.MDMServerTokenFile.ValueString()), diags
+	}
+}
+
+// adeStateTokenFingerprint returns the fingerprint of the token recorded in
+// state. State written before mdm_server_token_sha256 existed only has the
+// legacy mdm_server_token_file, so the fingerprint is derived from it.
+func adeStateTokenFingerprint(state adeIntegrationResourceModel) types.String {
+	if state.MDMServerTokenHash.IsNull() && !state.MDMServerTokenFile.IsNull() && !state.MDMServerTokenFile.IsUnknown() {
+		return types.StringValue(adeTokenFingerprint([]byte(state.MDMServerTokenFile.ValueString())))
+	}
+	return state.MDMServerTokenHash
+}
+
+// adeTokenChanged reports whether the planned token fingerprint differs from
+// the one in state. When state has no fingerprint at all, such as after an
+// import, the uploaded token is unknown and the integration is not renewed.
+func adeTokenChanged(fingerprint types.String, state adeIntegrationResourceModel) bool {
+	current := adeStateTokenFingerprint(state)
+	if current.IsNull() {
+		return false
+	}
+	return !fingerprint.Equal(current)
+}
+
+// adeTokenFingerprint returns the hex-encoded SHA-256 digest of a token.
+func adeTokenFingerprint(token []byte) string {
+	sum := sha256.Sum256(token)
+	return hex.EncodeToString(sum[:])
+}
+
+// adeTokenExpiryWarnings describes why the current token needs renewing soon,
+// if it does. A threshold of zero or less disables the warnings.
+func adeTokenExpiryWarnings(daysLeft types.Int64, accessTokenExpiry string, threshold int64, now time.Time) []string {
+	if threshold <= 0 {
+		return nil
+	}
+
+	var warnings []string
+	if !daysLeft.IsNull() && !daysLeft.IsUnknown() && daysLeft.ValueInt64() < threshold {
+		warnings = append(warnings, fmt.Sprintf("The MDM server token expires in %d days. Download a new token from Apple Business Manager and update the token attribute to renew it.", daysLeft.ValueInt64()))
+	}
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"} {
+		expiry, err := time.Parse(layout, accessTokenExpiry)
+		if err != nil {
+			continue
+		}
+		if expiry.Before(now.Add(time.Duration(threshold) * 24 * time.Hour)) {
+			warnings = append(warnings, fmt.Sprintf("The ADE access token expires at %s, which is within %d days.", expiry.Format(time.RFC3339), threshold))
+		}
+		break
+	}
+
+	return warnings
+}
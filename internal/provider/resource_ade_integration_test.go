@@ -1,8 +1,13 @@
 package provider
 
 import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -28,3 +33,125 @@ resource "iru_ade_integration" "test" {
 		},
 	})
 }
+
+func TestADEServerToken(t *testing.T) {
+	tokenPath := filepath.Join(t.TempDir(), "token.p7m")
+	if err := os.WriteFile(tokenPath, []byte("token-content"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	expected := adeTokenFingerprint([]byte("token-content"))
+
+	cases := map[string]adeIntegrationResourceModel{
+		"write-only": {
+			MDMServerTokenFile: types.StringNull(),
+			MDMServerTokenWO:   types.StringValue(base64.StdEncoding.EncodeToString([]byte("token-content"))),
+			MDMServerTokenPath: types.StringNull(),
+		},
+		"path": {
+			MDMServerTokenFile: types.StringNull(),
+			MDMServerTokenWO:   types.StringNull(),
+			MDMServerTokenPath: types.StringValue(tokenPath),
+		},
+		"file": {
+			MDMServerTokenFile: types.StringValue("token-content"),
+			MDMServerTokenWO:   types.StringNull(),
+			MDMServerTokenPath: types.StringNull(),
+		},
+	}
+	for name, config := range cases {
+		t.Run(name, func(t *testing.T) {
+			token, diags := adeServerToken(config)
+			if diags.HasError() {
+				t.Fatalf("Expected no error, got %v", diags)
+			}
+			if got := adeTokenFingerprint(token); got != expected {
+				t.Errorf("Expected fingerprint %s, got %s", expected, got)
+			}
+		})
+	}
+
+	t.Run("none set", func(t *testing.T) {
+		_, diags := adeServerToken(adeIntegrationResourceModel{
+			MDMServerTokenFile: types.StringNull(),
+			MDMServerTokenWO:   types.StringNull(),
+			MDMServerTokenPath: types.StringNull(),
+		})
+		if !diags.HasError() {
+			t.Error("Expected an error when no token is set")
+		}
+	})
+}
+
+func TestADETokenExpiryWarnings(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name      string
+		daysLeft  types.Int64
+		expiry    string
+		threshold int64
+		expected  int
+	}{
+		{"healthy", types.Int64Value(200), "2026-07-20T00:00:00Z", 30, 0},
+		{"days left below threshold", types.Int64Value(10), "2026-07-20T00:00:00Z", 30, 1},
+		{"access token expiring", types.Int64Value(200), "2026-01-15", 30, 1},
+		{"both", types.Int64Value(5), "2026-01-05T00:00:00", 30, 2},
+		{"disabled", types.Int64Value(5), "2026-01-05", 0, 0},
+		{"unknown values", types.Int64Null(), "", 30, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			warnings := adeTokenExpiryWarnings(tc.daysLeft, tc.expiry, tc.threshold, now)
+			if len(warnings) != tc.expected {
+				t.Errorf("Expected %d warnings, got %v", tc.expected, warnings)
+			}
+		})
+	}
+}
+
+func TestADETokenChanged(t *testing.T) {
+	fingerprint := types.StringValue(adeTokenFingerprint([]byte("token-content")))
+
+	cases := []struct {
+		name     string
+		state    adeIntegrationResourceModel
+		expected bool
+	}{
+		{
+			name:     "same fingerprint",
+			state:    adeIntegrationResourceModel{MDMServerTokenHash: fingerprint, MDMServerTokenFile: types.StringNull()},
+			expected: false,
+		},
+		{
+			name:     "new token",
+			state:    adeIntegrationResourceModel{MDMServerTokenHash: types.StringValue(adeTokenFingerprint([]byte("old"))), MDMServerTokenFile: types.StringNull()},
+			expected: true,
+		},
+		{
+			name:     "state from before fingerprints with the same token",
+			state:    adeIntegrationResourceModel{MDMServerTokenHash: types.StringNull(), MDMServerTokenFile: types.StringValue("token-content")},
+			expected: false,
+		},
+		{
+			name:     "state from before fingerprints with a new token",
+			state:    adeIntegrationResourceModel{MDMServerTokenHash: types.StringNull(), MDMServerTokenFile: types.StringValue("old")},
+			expected: true,
+		},
+		{
+			name:     "imported",
+			state:    adeIntegrationResourceModel{MDMServerTokenHash: types.StringNull(), MDMServerTokenFile: types.StringNull()},
+			expected: false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := adeTokenChanged(fingerprint, tc.state); got != tc.expected {
+				t.Errorf("Expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+
+	if got := adeTokenChanged(types.StringUnknown(), adeIntegrationResourceModel{MDMServerTokenHash: fingerprint, MDMServerTokenFile: types.StringNull()}); !got {
+		t.Error("Expected an unknown fingerprint to count as a change")
+	}
+}
@@ -259,6 +259,15 @@ func (r *blueprintResource) Delete(ctx context.Context, req resource.DeleteReque
 }
 
 func (r *blueprintResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, diags := resolveImportID(ctx, r.client, "Blueprint", "/api/v1/blueprints", req.ID, func(b client.Blueprint) (string, string) {
+		return b.ID, b.Name
+	})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	req.ID = id
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
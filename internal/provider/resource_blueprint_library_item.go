@@ -3,9 +3,12 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -13,6 +16,8 @@ import (
 )
 
 var _ resource.Resource = &blueprintLibraryItemResource{}
+var _ resource.ResourceWithImportState = &blueprintLibraryItemResource{}
+var _ resource.ResourceWithIdentity = &blueprintLibraryItemResource{}
 
 func NewBlueprintLibraryItemResource() resource.Resource {
 	return &blueprintLibraryItemResource{}
@@ -29,6 +34,10 @@ type blueprintLibraryItemResourceModel struct {
 	AssignmentNodeID types.String `tfsdk:"assignment_node_id"`
 }
 
+type blueprintLibraryItemResourceIdentityModel struct {
+	ID types.String `tfsdk:"id"`
+}
+
 func (r *blueprintLibraryItemResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_blueprint_library_item"
 }
@@ -69,6 +78,17 @@ func (r *blueprintLibraryItemResource) Schema(ctx context.Context, req resource.
 	}
 }
 
+func (r *blueprintLibraryItemResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The unique identifier for the assignment (format: blueprint_id:library_item_id).",
+			},
+		},
+	}
+}
+
 func (r *blueprintLibraryItemResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -103,6 +123,11 @@ func (r *blueprintLibraryItemResource) Create(ctx context.Context, req resource.
 
 	data.ID = types.StringValue(fmt.Sprintf("%s:%s", bpID, itemID))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	identity := blueprintLibraryItemResourceIdentityModel{
+		ID: data.ID,
+	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, &identity)...)
 }
 
 func (r *blueprintLibraryItemResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -112,6 +137,29 @@ func (r *blueprintLibraryItemResource) Read(ctx context.Context, req resource.Re
 		return
 	}
 
+	var identity blueprintLibraryItemResourceIdentityModel
+	resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := data.ID.ValueString()
+	if id == "" {
+		id = identity.ID.ValueString()
+	}
+
+	// Imported assignments only know their ID.
+	if data.BlueprintID.IsNull() || data.LibraryItemID.IsNull() {
+		idParts := strings.Split(id, ":")
+		if len(idParts) != 2 {
+			resp.Diagnostics.AddError("Invalid ID", "The ID must be in the format blueprint_id:library_item_id")
+			return
+		}
+		data.ID = types.StringValue(id)
+		data.BlueprintID = types.StringValue(idParts[0])
+		data.LibraryItemID = types.StringValue(idParts[1])
+	}
+
 	// Verify assignment via List
 	// GET /blueprints/{id}/library-items
 	var items []client.BlueprintLibraryItem
@@ -135,6 +183,9 @@ func (r *blueprintLibraryItemResource) Read(ctx context.Context, req resource.Re
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	identity.ID = data.ID
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, &identity)...)
 }
 
 func (r *blueprintLibraryItemResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -155,3 +206,7 @@ func (r *blueprintLibraryItemResource) Delete(ctx context.Context, req resource.
 		return
 	}
 }
+
+func (r *blueprintLibraryItemResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
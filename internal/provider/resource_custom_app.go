@@ -17,6 +17,7 @@ import (
 var _ resource.Resource = &customAppResource{}
 var _ resource.ResourceWithImportState = &customAppResource{}
 var _ resource.ResourceWithIdentity = &customAppResource{}
+var _ resource.ResourceWithValidateConfig = &customAppResource{}
 
 func NewCustomAppResource() resource.Resource {
 	return &customAppResource{}
@@ -138,6 +139,17 @@ func (r *customAppResource) IdentitySchema(ctx context.Context, req resource.Ide
 	}
 }
 
+func (r *customAppResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data customAppResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateSelfServiceCategory(data.ShowInSelfService, data.SelfServiceCategoryID)...)
+}
+
 func (r *customAppResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -262,6 +262,15 @@ func (r *customProfileResource) Delete(ctx context.Context, req resource.DeleteR
 }
 
 func (r *customProfileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, diags := resolveImportID(ctx, r.client, "Custom Profile", "/api/v1/library/custom-profiles", req.ID, func(c client.CustomProfile) (string, string) {
+		return c.ID, c.Name
+	})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	req.ID = id
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
@@ -17,6 +17,7 @@ import (
 var _ resource.Resource = &customScriptResource{}
 var _ resource.ResourceWithImportState = &customScriptResource{}
 var _ resource.ResourceWithIdentity = &customScriptResource{}
+var _ resource.ResourceWithValidateConfig = &customScriptResource{}
 
 func NewCustomScriptResource() resource.Resource {
 	return &customScriptResource{}
@@ -27,14 +28,16 @@ type customScriptResource struct {
 }
 
 type customScriptResourceModel struct {
-	ID                 types.String `tfsdk:"id"`
-	Name               types.String `tfsdk:"name"`
-	Active             types.Bool   `tfsdk:"active"`
-	ExecutionFrequency types.String `tfsdk:"execution_frequency"`
-	Restart            types.Bool   `tfsdk:"restart"`
-	Script             types.String `tfsdk:"script"`
-	RemediationScript  types.String `tfsdk:"remediation_script"`
-	ShowInSelfService  types.Bool   `tfsdk:"show_in_self_service"`
+	ID                     types.String `tfsdk:"id"`
+	Name                   types.String `tfsdk:"name"`
+	Active                 types.Bool   `tfsdk:"active"`
+	ExecutionFrequency     types.String `tfsdk:"execution_frequency"`
+	Restart                types.Bool   `tfsdk:"restart"`
+	Script                 types.String `tfsdk:"script"`
+	RemediationScript      types.String `tfsdk:"remediation_script"`
+	ShowInSelfService      types.Bool   `tfsdk:"show_in_self_service"`
+	SelfServiceCategoryID  types.String `tfsdk:"self_service_category_id"`
+	SelfServiceRecommended types.Bool   `tfsdk:"self_service_recommended"`
 }
 
 type customScriptResourceIdentityModel struct {
@@ -50,7 +53,7 @@ func (r *customScriptResource) Schema(ctx context.Context, req resource.SchemaRe
 		MarkdownDescription: "Manages an Iru Custom Script library item.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Computed:            true,
+				Computed:    true,
 				Description: "The unique identifier for the Custom Script.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
@@ -88,6 +91,16 @@ func (r *customScriptResource) Schema(ctx context.Context, req resource.SchemaRe
 				Computed:            true,
 				MarkdownDescription: "Whether to make this script available for users to run manually in the Self Service app.",
 			},
+			"self_service_category_id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The UUID of the Self Service category to display the script in. Required if `show_in_self_service` is `true`.",
+			},
+			"self_service_recommended": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Whether to flag this script as recommended in Self Service.",
+			},
 		},
 	}
 }
@@ -103,6 +116,17 @@ func (r *customScriptResource) IdentitySchema(ctx context.Context, req resource.
 	}
 }
 
+func (r *customScriptResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data customScriptResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateSelfServiceCategory(data.ShowInSelfService, data.SelfServiceCategoryID)...)
+}
+
 func (r *customScriptResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -129,13 +153,15 @@ func (r *customScriptResource) Create(ctx context.Context, req resource.CreateRe
 	}
 
 	scriptRequest := client.CustomScript{
-		Name:               data.Name.ValueString(),
-		Active:             data.Active.ValueBool(),
-		ExecutionFrequency: data.ExecutionFrequency.ValueString(),
-		Restart:            data.Restart.ValueBool(),
-		Script:             data.Script.ValueString(),
-		RemediationScript:  data.RemediationScript.ValueString(),
-		ShowInSelfService:  data.ShowInSelfService.ValueBool(),
+		Name:                   data.Name.ValueString(),
+		Active:                 data.Active.ValueBool(),
+		ExecutionFrequency:     data.ExecutionFrequency.ValueString(),
+		Restart:                data.Restart.ValueBool(),
+		Script:                 data.Script.ValueString(),
+		RemediationScript:      data.RemediationScript.ValueString(),
+		ShowInSelfService:      data.ShowInSelfService.ValueBool(),
+		SelfServiceCategoryID:  data.SelfServiceCategoryID.ValueString(),
+		SelfServiceRecommended: data.SelfServiceRecommended.ValueBool(),
 	}
 
 	var scriptResponse client.CustomScript
@@ -153,6 +179,8 @@ func (r *customScriptResource) Create(ctx context.Context, req resource.CreateRe
 	data.Script = types.StringValue(scriptResponse.Script)
 	data.RemediationScript = types.StringValue(scriptResponse.RemediationScript)
 	data.ShowInSelfService = types.BoolValue(scriptResponse.ShowInSelfService)
+	data.SelfServiceCategoryID = types.StringValue(scriptResponse.SelfServiceCategoryID)
+	data.SelfServiceRecommended = types.BoolValue(scriptResponse.SelfServiceRecommended)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 
@@ -195,6 +223,8 @@ func (r *customScriptResource) Read(ctx context.Context, req resource.ReadReques
 	data.Script = types.StringValue(scriptResponse.Script)
 	data.RemediationScript = types.StringValue(scriptResponse.RemediationScript)
 	data.ShowInSelfService = types.BoolValue(scriptResponse.ShowInSelfService)
+	data.SelfServiceCategoryID = types.StringValue(scriptResponse.SelfServiceCategoryID)
+	data.SelfServiceRecommended = types.BoolValue(scriptResponse.SelfServiceRecommended)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 	resp.Diagnostics.Append(resp.Identity.Set(ctx, &identity)...)
@@ -209,13 +239,15 @@ func (r *customScriptResource) Update(ctx context.Context, req resource.UpdateRe
 	}
 
 	scriptRequest := client.CustomScript{
-		Name:               data.Name.ValueString(),
-		Active:             data.Active.ValueBool(),
-		ExecutionFrequency: data.ExecutionFrequency.ValueString(),
-		Restart:            data.Restart.ValueBool(),
-		Script:             data.Script.ValueString(),
-		RemediationScript:  data.RemediationScript.ValueString(),
-		ShowInSelfService:  data.ShowInSelfService.ValueBool(),
+		Name:                   data.Name.ValueString(),
+		Active:                 data.Active.ValueBool(),
+		ExecutionFrequency:     data.ExecutionFrequency.ValueString(),
+		Restart:                data.Restart.ValueBool(),
+		Script:                 data.Script.ValueString(),
+		RemediationScript:      data.RemediationScript.ValueString(),
+		ShowInSelfService:      data.ShowInSelfService.ValueBool(),
+		SelfServiceCategoryID:  data.SelfServiceCategoryID.ValueString(),
+		SelfServiceRecommended: data.SelfServiceRecommended.ValueBool(),
 	}
 
 	var scriptResponse client.CustomScript
@@ -232,6 +264,8 @@ func (r *customScriptResource) Update(ctx context.Context, req resource.UpdateRe
 	data.Script = types.StringValue(scriptResponse.Script)
 	data.RemediationScript = types.StringValue(scriptResponse.RemediationScript)
 	data.ShowInSelfService = types.BoolValue(scriptResponse.ShowInSelfService)
+	data.SelfServiceCategoryID = types.StringValue(scriptResponse.SelfServiceCategoryID)
+	data.SelfServiceRecommended = types.BoolValue(scriptResponse.SelfServiceRecommended)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 
@@ -257,5 +291,14 @@ func (r *customScriptResource) Delete(ctx context.Context, req resource.DeleteRe
 }
 
 func (r *customScriptResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, diags := resolveImportID(ctx, r.client, "Custom Script", "/api/v1/library/custom-scripts", req.ID, func(c client.CustomScript) (string, string) {
+		return c.ID, c.Name
+	})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	req.ID = id
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
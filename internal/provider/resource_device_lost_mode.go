@@ -0,0 +1,296 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = &deviceLostModeResource{}
+var _ resource.ResourceWithImportState = &deviceLostModeResource{}
+var _ resource.ResourceWithIdentity = &deviceLostModeResource{}
+
+func NewDeviceLostModeResource() resource.Resource {
+	return &deviceLostModeResource{}
+}
+
+type deviceLostModeResource struct {
+	client *client.Client
+}
+
+type deviceLostModeResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	DeviceID    types.String `tfsdk:"device_id"`
+	Message     types.String `tfsdk:"message"`
+	PhoneNumber types.String `tfsdk:"phone_number"`
+	Footnote    types.String `tfsdk:"footnote"`
+	Status      types.String `tfsdk:"status"`
+}
+
+type deviceLostModeResourceIdentityModel struct {
+	ID types.String `tfsdk:"id"`
+}
+
+func (r *deviceLostModeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_lost_mode"
+}
+
+func (r *deviceLostModeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages Lost Mode on a specific device. Creating this resource enables Lost Mode and destroying it disables Lost Mode. If the device leaves Lost Mode outside of Terraform, the next plan will enable it again.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The unique identifier for the Device.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"device_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The unique identifier for the Device.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"message": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Message to display on the lost device.",
+			},
+			"phone_number": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Phone number to display on the lost device.",
+			},
+			"footnote": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Footnote to display on the lost device.",
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The Lost Mode status reported for the device.",
+			},
+		},
+	}
+}
+
+func (r *deviceLostModeResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The unique identifier for the Device.",
+			},
+		},
+	}
+}
+
+func (r *deviceLostModeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *deviceLostModeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data deviceLostModeResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deviceID := data.DeviceID.ValueString()
+	err := r.client.DoRequest(ctx, "POST", fmt.Sprintf("/api/v1/devices/%s/action/enablelostmode", deviceID), lostModePayload(data), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to enable lost mode, got error: %s", err))
+		return
+	}
+
+	var lostMode client.DeviceLostMode
+	err = r.client.DoRequest(ctx, "GET", fmt.Sprintf("/api/v1/devices/%s/details/lostmode", deviceID), nil, &lostMode)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read device lost mode details, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue(deviceID)
+	data.Status = types.StringValue(lostMode.Status)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	identity := deviceLostModeResourceIdentityModel{
+		ID: data.ID,
+	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, &identity)...)
+}
+
+func (r *deviceLostModeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data deviceLostModeResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var identity deviceLostModeResourceIdentityModel
+	resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := data.ID.ValueString()
+	if id == "" {
+		id = identity.ID.ValueString()
+	}
+
+	var lostMode client.DeviceLostMode
+	err := r.client.DoRequest(ctx, "GET", fmt.Sprintf("/api/v1/devices/%s/details/lostmode", id), nil, &lostMode)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read device lost mode details, got error: %s", err))
+		return
+	}
+
+	// The device left Lost Mode outside of Terraform, so the next plan
+	// should enable it again.
+	if !lostModeEnabled(ctx, lostMode.Status) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.ID = types.StringValue(id)
+	data.DeviceID = types.StringValue(id)
+	data.Message = lostModeValue(data.Message, lostMode.Message)
+	data.PhoneNumber = lostModeValue(data.PhoneNumber, lostMode.PhoneNumber)
+	data.Footnote = lostModeValue(data.Footnote, lostMode.Footnote)
+	data.Status = types.StringValue(lostMode.Status)
+
+	identity.ID = data.ID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, &identity)...)
+}
+
+func (r *deviceLostModeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data deviceLostModeResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Enabling Lost Mode again replaces the message, phone number and footnote
+	// shown on the device.
+	deviceID := data.DeviceID.ValueString()
+	err := r.client.DoRequest(ctx, "POST", fmt.Sprintf("/api/v1/devices/%s/action/enablelostmode", deviceID), lostModePayload(data), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update lost mode, got error: %s", err))
+		return
+	}
+
+	var lostMode client.DeviceLostMode
+	err = r.client.DoRequest(ctx, "GET", fmt.Sprintf("/api/v1/devices/%s/details/lostmode", deviceID), nil, &lostMode)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read device lost mode details, got error: %s", err))
+		return
+	}
+
+	data.Status = types.StringValue(lostMode.Status)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	identity := deviceLostModeResourceIdentityModel{
+		ID: data.ID,
+	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, &identity)...)
+}
+
+func (r *deviceLostModeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data deviceLostModeResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DoRequest(ctx, "POST", fmt.Sprintf("/api/v1/devices/%s/action/disablelostmode", data.DeviceID.ValueString()), nil, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to disable lost mode, got error: %s", err))
+		return
+	}
+}
+
+func (r *deviceLostModeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughID(ctx, path.Root("device_id"), req, resp)
+}
+
+func lostModePayload(data deviceLostModeResourceModel) map[string]string {
+	payload := map[string]string{}
+	if !data.Message.IsNull() {
+		payload["Message"] = data.Message.ValueString()
+	}
+	if !data.PhoneNumber.IsNull() {
+		payload["PhoneNumber"] = data.PhoneNumber.ValueString()
+	}
+	if !data.Footnote.IsNull() {
+		payload["Footnote"] = data.Footnote.ValueString()
+	}
+	return payload
+}
+
+// lostModeStatuses maps the Lost Mode statuses reported by the Lost Mode
+// details endpoint to whether the device is in, or is being placed into, Lost
+// Mode. A device waiting for a disable command has not left Lost Mode yet, but
+// Terraform already asked for it to.
+var lostModeStatuses = map[string]bool{
+	"":                false,
+	"disabled":        false,
+	"not_enabled":     false,
+	"disable_pending": false,
+	"enabled":         true,
+	"enable_pending":  true,
+	"pending":         true,
+}
+
+// lostModeEnabled reports whether a Lost Mode status means the device is in,
+// or is being placed into, Lost Mode. Unrecognized statuses are logged and
+// treated as enabled, so that a new status never removes the resource from
+// state.
+func lostModeEnabled(ctx context.Context, status string) bool {
+	enabled, ok := lostModeStatuses[strings.ToLower(strings.TrimSpace(status))]
+	if !ok {
+		tflog.Warn(ctx, "Unrecognized Lost Mode status, treating it as enabled", map[string]interface{}{
+			"status": status,
+		})
+		return true
+	}
+	return enabled
+}
+
+// lostModeValue keeps an unset optional attribute null when the API reports
+// an empty value, so an omitted message does not show up as drift.
+func lostModeValue(current types.String, value string) types.String {
+	if value == "" && current.IsNull() {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDeviceLostModeResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "iru_device_lost_mode" "test" {
+  device_id    = "PLACEHOLDER"
+  message      = "tf-acc-test"
+  phone_number = "555-123-4567"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("iru_device_lost_mode.test", "message", "tf-acc-test"),
+					resource.TestCheckResourceAttrSet("iru_device_lost_mode.test", "status"),
+				),
+			},
+		},
+	})
+}
+
+func TestLostModeEnabled(t *testing.T) {
+	for _, tc := range []struct {
+		status string
+		want   bool
+	}{
+		{"", false},
+		{"disabled", false},
+		{"DISABLED", false},
+		{"not_enabled", false},
+		{"disable_pending", false},
+		{"enabled", true},
+		{" Enabled ", true},
+		{"enable_pending", true},
+		{"pending", true},
+		{"locating", true},
+	} {
+		if got := lostModeEnabled(context.Background(), tc.status); got != tc.want {
+			t.Errorf("Expected status %q to report enabled=%v, got %v", tc.status, tc.want, got)
+		}
+	}
+}
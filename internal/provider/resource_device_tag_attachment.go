@@ -0,0 +1,222 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = &deviceTagAttachmentResource{}
+var _ resource.ResourceWithImportState = &deviceTagAttachmentResource{}
+var _ resource.ResourceWithIdentity = &deviceTagAttachmentResource{}
+
+func NewDeviceTagAttachmentResource() resource.Resource {
+	return &deviceTagAttachmentResource{}
+}
+
+type deviceTagAttachmentResource struct {
+	client *client.Client
+}
+
+type deviceTagAttachmentResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	DeviceID types.String `tfsdk:"device_id"`
+	TagID    types.String `tfsdk:"tag_id"`
+}
+
+type deviceTagAttachmentResourceIdentityModel struct {
+	ID types.String `tfsdk:"id"`
+}
+
+func (r *deviceTagAttachmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_tag_attachment"
+}
+
+func (r *deviceTagAttachmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Attaches a single Tag to a Device. This resource is non-authoritative: other tags of the device are left untouched. Do not combine it with `iru_device_tags` for the same device.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The unique identifier for the attachment (composite key).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"device_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The UUID of the device.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tag_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The UUID of the tag.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *deviceTagAttachmentResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The unique identifier for the attachment (format: device_id:tag_id).",
+			},
+		},
+	}
+}
+
+func (r *deviceTagAttachmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *deviceTagAttachmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data deviceTagAttachmentResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deviceID := data.DeviceID.ValueString()
+	tagID := data.TagID.ValueString()
+
+	unlock := lockDeviceTags(deviceID)
+	defer unlock()
+
+	tagIDs, err := deviceTagIDs(ctx, r.client, deviceID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read device tags, got error: %s", err))
+		return
+	}
+
+	if !slices.Contains(tagIDs, tagID) {
+		err = setDeviceTagIDs(ctx, r.client, deviceID, append(tagIDs, tagID))
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to attach tag, got error: %s", err))
+			return
+		}
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%s:%s", deviceID, tagID))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	identity := deviceTagAttachmentResourceIdentityModel{
+		ID: data.ID,
+	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, &identity)...)
+}
+
+func (r *deviceTagAttachmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data deviceTagAttachmentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var identity deviceTagAttachmentResourceIdentityModel
+	resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := data.ID.ValueString()
+	if id == "" {
+		id = identity.ID.ValueString()
+	}
+
+	idParts := strings.Split(id, ":")
+	if len(idParts) != 2 {
+		resp.Diagnostics.AddError("Invalid ID", "The ID must be in the format device_id:tag_id")
+		return
+	}
+	deviceID := idParts[0]
+	tagID := idParts[1]
+
+	tagIDs, err := deviceTagIDs(ctx, r.client, deviceID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read device tags, got error: %s", err))
+		return
+	}
+
+	if !slices.Contains(tagIDs, tagID) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.ID = types.StringValue(id)
+	data.DeviceID = types.StringValue(deviceID)
+	data.TagID = types.StringValue(tagID)
+	identity.ID = data.ID
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, &identity)...)
+}
+
+func (r *deviceTagAttachmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Not supported, requires replace (handled by schema)
+}
+
+func (r *deviceTagAttachmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data deviceTagAttachmentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deviceID := data.DeviceID.ValueString()
+	tagID := data.TagID.ValueString()
+
+	unlock := lockDeviceTags(deviceID)
+	defer unlock()
+
+	tagIDs, err := deviceTagIDs(ctx, r.client, deviceID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read device tags, got error: %s", err))
+		return
+	}
+
+	if !slices.Contains(tagIDs, tagID) {
+		return
+	}
+
+	remaining := slices.DeleteFunc(tagIDs, func(id string) bool { return id == tagID })
+	err = setDeviceTagIDs(ctx, r.client, deviceID, remaining)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to detach tag, got error: %s", err))
+		return
+	}
+}
+
+func (r *deviceTagAttachmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,258 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = &deviceTagsResource{}
+var _ resource.ResourceWithImportState = &deviceTagsResource{}
+var _ resource.ResourceWithIdentity = &deviceTagsResource{}
+
+func NewDeviceTagsResource() resource.Resource {
+	return &deviceTagsResource{}
+}
+
+type deviceTagsResource struct {
+	client *client.Client
+}
+
+type deviceTagsResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	DeviceID types.String `tfsdk:"device_id"`
+	TagIDs   types.Set    `tfsdk:"tag_ids"`
+}
+
+type deviceTagsResourceIdentityModel struct {
+	ID types.String `tfsdk:"id"`
+}
+
+func (r *deviceTagsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_tags"
+}
+
+func (r *deviceTagsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the complete set of Tags assigned to a Device. This resource is authoritative: tags that are not listed are removed from the device. Do not combine it with `iru_device_tag_attachment` for the same device.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The unique identifier for the Device.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"device_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The UUID of the device.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tag_ids": schema.SetAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The UUIDs of the tags assigned to the device. Use an empty set to remove every tag.",
+			},
+		},
+	}
+}
+
+func (r *deviceTagsResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The unique identifier for the Device.",
+			},
+		},
+	}
+}
+
+func (r *deviceTagsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *deviceTagsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data deviceTagsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	identity := deviceTagsResourceIdentityModel{
+		ID: data.ID,
+	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, &identity)...)
+}
+
+func (r *deviceTagsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data deviceTagsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var identity deviceTagsResourceIdentityModel
+	resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := data.ID.ValueString()
+	if id == "" {
+		id = identity.ID.ValueString()
+	}
+
+	tagIDs, err := deviceTagIDs(ctx, r.client, id)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read device tags, got error: %s", err))
+		return
+	}
+
+	tagSet, diags := types.SetValueFrom(ctx, types.StringType, tagIDs)
+	resp.Diagnostics.Append(diags...)
+
+	data.ID = types.StringValue(id)
+	data.DeviceID = types.StringValue(id)
+	data.TagIDs = tagSet
+	identity.ID = data.ID
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, &identity)...)
+}
+
+func (r *deviceTagsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data deviceTagsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	identity := deviceTagsResourceIdentityModel{
+		ID: data.ID,
+	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, &identity)...)
+}
+
+func (r *deviceTagsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data deviceTagsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	unlock := lockDeviceTags(data.DeviceID.ValueString())
+	defer unlock()
+
+	err := setDeviceTagIDs(ctx, r.client, data.DeviceID.ValueString(), []string{})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove device tags, got error: %s", err))
+		return
+	}
+}
+
+func (r *deviceTagsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// apply replaces the tags of the device with the planned tags.
+func (r *deviceTagsResource) apply(ctx context.Context, data *deviceTagsResourceModel) diag.Diagnostics {
+	var tagIDs []string
+	diags := data.TagIDs.ElementsAs(ctx, &tagIDs, false)
+	if diags.HasError() {
+		return diags
+	}
+
+	deviceID := data.DeviceID.ValueString()
+	unlock := lockDeviceTags(deviceID)
+	defer unlock()
+
+	err := setDeviceTagIDs(ctx, r.client, deviceID, tagIDs)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to set device tags, got error: %s", err))
+		return diags
+	}
+
+	data.ID = types.StringValue(deviceID)
+	return diags
+}
+
+// deviceTagLocks serializes tag changes per device. Tags are replaced as a
+// whole, so concurrent attachments to one device would otherwise overwrite
+// each other.
+var deviceTagLocks sync.Map
+
+func lockDeviceTags(deviceID string) (unlock func()) {
+	value, _ := deviceTagLocks.LoadOrStore(deviceID, &sync.Mutex{})
+	mu := value.(*sync.Mutex)
+	mu.Lock()
+	return mu.Unlock
+}
+
+// deviceTagIDs returns the sorted IDs of the tags assigned to a device.
+func deviceTagIDs(ctx context.Context, c *client.Client, deviceID string) ([]string, error) {
+	var device client.Device
+	err := c.DoRequest(ctx, "GET", "/api/v1/devices/"+deviceID, nil, &device)
+	if err != nil {
+		return nil, err
+	}
+
+	tagIDs := make([]string, 0, len(device.Tags))
+	for _, tag := range device.Tags {
+		tagIDs = append(tagIDs, tag.ID)
+	}
+	slices.Sort(tagIDs)
+	return tagIDs, nil
+}
+
+// setDeviceTagIDs replaces the tags assigned to a device.
+func setDeviceTagIDs(ctx context.Context, c *client.Client, deviceID string, tagIDs []string) error {
+	updateRequest := map[string]interface{}{
+		"tags": tagIDs,
+	}
+	return c.DoRequest(ctx, "PATCH", "/api/v1/devices/"+deviceID, updateRequest, nil)
+}
@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+)
+
+func TestDeviceTagIDs(t *testing.T) {
+	var patched []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/devices/d1" {
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+		switch r.Method {
+		case "GET":
+			_, _ = w.Write([]byte(`{"device_id": "d1", "tags": [{"id": "t2", "name": "b"}, {"id": "t1", "name": "a"}]}`))
+		case "PATCH":
+			var body struct {
+				Tags []string `json:"tags"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("Expected a JSON body, got error: %v", err)
+			}
+			patched = body.Tags
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	c := client.NewClient(server.URL, "test-token")
+	tagIDs, err := deviceTagIDs(context.Background(), c, "d1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !slices.Equal(tagIDs, []string{"t1", "t2"}) {
+		t.Errorf("Expected sorted tag IDs [t1 t2], got %v", tagIDs)
+	}
+
+	if err := setDeviceTagIDs(context.Background(), c, "d1", []string{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if patched == nil || len(patched) != 0 {
+		t.Errorf("Expected an empty tag list to be sent, got %v", patched)
+	}
+}
+
+func TestFindTagByName(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("name"); got != "dept" {
+			t.Errorf("Expected name=dept, got %q", got)
+		}
+		_, _ = w.Write([]byte(`{"results": [{"id": "t1", "name": "dept-eng"}, {"id": "t2", "name": "dept"}]}`))
+	}))
+	defer server.Close()
+
+	c := client.NewClient(server.URL, "test-token")
+	tag, found, err := findTagByName(context.Background(), c, "dept")
+	if err != nil || !found || tag.ID != "t2" {
+		t.Errorf("Expected tag t2, got %v (found %t, error %v)", tag, found, err)
+	}
+}
@@ -1,34 +1,63 @@
 package provider
 
 import (
+	"bytes"
 	"context"
+	"encoding/csv"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"time"
 
 	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-var _ resource.Resource = &prismExportResource{}
+var (
+	_ resource.Resource                   = &prismExportResource{}
+	_ resource.ResourceWithValidateConfig = &prismExportResource{}
+)
+
+const (
+	defaultPrismExportTimeout      = 10 * time.Minute
+	defaultPrismExportPollInterval = 10 * time.Second
+)
 
 func NewPrismExportResource() resource.Resource {
-	return &prismExportResource{}
+	return &prismExportResource{
+		after: time.After,
+	}
 }
 
 type prismExportResource struct {
 	client *client.Client
+	after  func(time.Duration) <-chan time.Time
 }
 
 type prismExportResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	Category       types.String `tfsdk:"category"`
-	BlueprintIDs   types.List   `tfsdk:"blueprint_ids"`
-	DeviceFamilies types.List   `tfsdk:"device_families"`
-	Status         types.String `tfsdk:"status"`
-	SignedURL      types.String `tfsdk:"signed_url"`
+	ID                types.String `tfsdk:"id"`
+	Category          types.String `tfsdk:"category"`
+	BlueprintIDs      types.List   `tfsdk:"blueprint_ids"`
+	DeviceFamilies    types.List   `tfsdk:"device_families"`
+	Filter            types.Object `tfsdk:"filter"`
+	SortBy            types.String `tfsdk:"sort_by"`
+	WaitForCompletion types.Bool   `tfsdk:"wait_for_completion"`
+	Timeout           types.String `tfsdk:"timeout"`
+	PollInterval      types.String `tfsdk:"poll_interval"`
+	OutputPath        types.String `tfsdk:"output_path"`
+	ParseCSV          types.Bool   `tfsdk:"parse_csv"`
+	Status            types.String `tfsdk:"status"`
+	SignedURL         types.String `tfsdk:"signed_url"`
+	Rows              types.List   `tfsdk:"rows"`
 }
 
 func (r *prismExportResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -37,11 +66,11 @@ func (r *prismExportResource) Metadata(ctx context.Context, req resource.Metadat
 
 func (r *prismExportResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Request and retrieve a Prism category export. This resource initiates an asynchronous export job and provides a signed URL to download the results once complete. Note: Signed URLs are temporary.",
+		MarkdownDescription: "Request and retrieve a Prism category export. This resource initiates an asynchronous export job and provides a signed URL to download the results once complete. Set `wait_for_completion` to wait for the job during apply, then optionally save the CSV to `output_path` or parse it into `rows`. Note: Signed URLs are temporary.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Computed:            true,
-				Description:         "The unique identifier for the export job.",
+				Computed:    true,
+				Description: "The unique identifier for the export job.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
@@ -58,13 +87,44 @@ func (r *prismExportResource) Schema(ctx context.Context, req resource.SchemaReq
 				ElementType:         types.StringType,
 				MarkdownDescription: "List of blueprint IDs to filter by.",
 				PlanModifiers: []planmodifier.List{
-					// listplanmodifier.RequiresReplace(), // Requires import of listplanmodifier or custom
+					listplanmodifier.RequiresReplace(),
 				},
 			},
 			"device_families": schema.ListAttribute{
 				Optional:            true,
 				ElementType:         types.StringType,
 				MarkdownDescription: "List of device families to filter by.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"filter": prismFilterResourceAttribute(),
+			"sort_by": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: prismSortByDescription,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait_for_completion": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Wait until the export job succeeds or fails. A failed job fails the apply. Defaults to `false`.",
+			},
+			"timeout": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "How long to wait for the export job, as a Go duration such as `30m`. Defaults to `10m`.",
+			},
+			"poll_interval": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "How long to wait between status checks, as a Go duration such as `30s`. Defaults to `10s`.",
+			},
+			"output_path": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "A local file to save the exported CSV to once the job succeeds. Requires `wait_for_completion`.",
+			},
+			"parse_csv": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Parse the exported CSV into `rows` once the job succeeds. Requires `wait_for_completion`. Defaults to `false`.",
 			},
 			"status": schema.StringAttribute{
 				Computed:            true,
@@ -74,6 +134,11 @@ func (r *prismExportResource) Schema(ctx context.Context, req resource.SchemaReq
 				Computed:            true,
 				MarkdownDescription: "The signed URL to download the export.",
 			},
+			"rows": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.MapType{ElemType: types.StringType},
+				MarkdownDescription: "The rows of the exported CSV, keyed by column name, when `parse_csv` is set.",
+			},
 		},
 	}
 }
@@ -85,48 +150,57 @@ func (r *prismExportResource) Configure(ctx context.Context, req resource.Config
 	r.client = req.ProviderData.(*client.Client)
 }
 
-func (r *prismExportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+func (r *prismExportResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
 	var data prismExportResourceModel
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	reqBody := map[string]interface{}{
-		"category": data.Category.ValueString(),
-	}
+	resp.Diagnostics.Append(validatePrismSortBy(data.Category, data.SortBy)...)
+	_, diags := buildPrismFilter(ctx, data.Category, data.Filter)
+	resp.Diagnostics.Append(diags...)
 
-	if !data.BlueprintIDs.IsNull() {
-		var bpIDs []string
-		data.BlueprintIDs.ElementsAs(ctx, &bpIDs, false)
-		reqBody["blueprint_ids"] = bpIDs
+	if data.WaitForCompletion.IsUnknown() || data.WaitForCompletion.ValueBool() {
+		return
+	}
+	if !data.OutputPath.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("output_path"), "Missing Wait For Completion", "`output_path` requires `wait_for_completion = true`.")
 	}
-	if !data.DeviceFamilies.IsNull() {
-		var families []string
-		data.DeviceFamilies.ElementsAs(ctx, &families, false)
-		reqBody["device_families"] = families
+	if data.ParseCSV.ValueBool() {
+		resp.Diagnostics.AddAttributeError(path.Root("parse_csv"), "Missing Wait For Completion", "`parse_csv` requires `wait_for_completion = true`.")
 	}
+}
 
-	// Filter and SortBy are omitted for simplicity as they are complex in schema
-	// Could implement if needed.
+func (r *prismExportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data prismExportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, diags := newPrismQuery(ctx, data.Category.ValueString(), data.BlueprintIDs, data.DeviceFamilies, data.Filter, data.SortBy)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	var exportResp client.PrismExport
-	err := r.client.DoRequest(ctx, "POST", "/api/v1/prism/export", reqBody, &exportResp)
+	err := r.client.DoRequest(ctx, "POST", "/api/v1/prism/export", query.body(), &exportResp)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create prism export, got error: %s", err))
 		return
 	}
 
-	// Wait for export to complete? Terraform resources usually represent the *request*.
-	// But the user might want the URL. We can stick to returning what we have (ID, Status).
-	// Typically users use a data source to read the result or we poll here.
-	// For "export" resource, usually it implies creating the job.
-	// Let's poll for a short time or just return. The API says "The id key is used when checking the export status".
-	// Let's just return the initial state.
-
 	data.ID = types.StringValue(exportResp.ID)
 	data.Status = types.StringValue(exportResp.Status)
-	// Category etc. are from plan.
+	data.SignedURL = types.StringValue(exportResp.SignedURL)
+	data.Rows = types.ListNull(types.MapType{ElemType: types.StringType})
+
+	resp.Diagnostics.Append(r.complete(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -152,10 +226,167 @@ func (r *prismExportResource) Read(ctx context.Context, req resource.ReadRequest
 }
 
 func (r *prismExportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// Exports are generally immutable.
-	resp.Diagnostics.AddError("Update Not Supported", "Prism exports cannot be updated. Force recreation.")
+	// Changes to the export itself replace it; only the wait and download
+	// settings can change in place.
+	var data, state prismExportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = state.ID
+	data.Status = state.Status
+	data.SignedURL = state.SignedURL
+	data.Rows = types.ListNull(types.MapType{ElemType: types.StringType})
+
+	resp.Diagnostics.Append(r.complete(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *prismExportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	// No delete endpoint for exports. Just remove from state.
 }
+
+// complete waits for the export when wait_for_completion is set, then saves
+// and parses the exported CSV as configured.
+func (r *prismExportResource) complete(ctx context.Context, data *prismExportResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !data.WaitForCompletion.ValueBool() {
+		return diags
+	}
+
+	timeout := defaultPrismExportTimeout
+	if !data.Timeout.IsNull() {
+		parsed, err := time.ParseDuration(data.Timeout.ValueString())
+		if err != nil || parsed <= 0 {
+			diags.AddAttributeError(path.Root("timeout"), "Invalid Timeout", fmt.Sprintf("`timeout` must be a positive duration such as `10m`, got %q.", data.Timeout.ValueString()))
+			return diags
+		}
+		timeout = parsed
+	}
+
+	interval := defaultPrismExportPollInterval
+	if !data.PollInterval.IsNull() {
+		parsed, err := time.ParseDuration(data.PollInterval.ValueString())
+		if err != nil || parsed <= 0 {
+			diags.AddAttributeError(path.Root("poll_interval"), "Invalid Poll Interval", fmt.Sprintf("`poll_interval` must be a positive duration such as `10s`, got %q.", data.PollInterval.ValueString()))
+			return diags
+		}
+		interval = parsed
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	export, err := r.waitForExport(waitCtx, data.ID.ValueString(), interval)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			diags.AddError("Export Timeout", fmt.Sprintf("Prism export %s did not complete within %s: %s", data.ID.ValueString(), timeout, err))
+			return diags
+		}
+		diags.AddError("Client Error", fmt.Sprintf("Unable to wait for prism export, got error: %s", err))
+		return diags
+	}
+
+	data.Status = types.StringValue(export.Status)
+	data.SignedURL = types.StringValue(export.SignedURL)
+	if export.Status == "failed" {
+		diags.AddError("Export Failed", fmt.Sprintf("Prism export %s of category %s failed.", export.ID, data.Category.ValueString()))
+		return diags
+	}
+
+	if data.OutputPath.IsNull() && !data.ParseCSV.ValueBool() {
+		return diags
+	}
+
+	var content bytes.Buffer
+	if err := r.client.Download(ctx, export.SignedURL, &content); err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to download prism export, got error: %s", err))
+		return diags
+	}
+
+	if !data.OutputPath.IsNull() {
+		if err := os.WriteFile(data.OutputPath.ValueString(), content.Bytes(), 0o600); err != nil {
+			diags.AddAttributeError(path.Root("output_path"), "Unable to Save Export", fmt.Sprintf("Unable to write %s, got error: %s", data.OutputPath.ValueString(), err))
+			return diags
+		}
+	}
+
+	if data.ParseCSV.ValueBool() {
+		rows, err := parseExportCSV(&content)
+		if err != nil {
+			diags.AddError("Invalid Export", fmt.Sprintf("Unable to parse prism export CSV, got error: %s", err))
+			return diags
+		}
+		var d diag.Diagnostics
+		data.Rows, d = types.ListValueFrom(ctx, types.MapType{ElemType: types.StringType}, rows)
+		diags.Append(d...)
+	}
+
+	return diags
+}
+
+// waitForExport polls the export until it succeeds or fails. It returns the
+// context error, annotated with the last status seen, when ctx ends first.
+func (r *prismExportResource) waitForExport(ctx context.Context, id string, interval time.Duration) (client.PrismExport, error) {
+	for {
+		var export client.PrismExport
+		err := r.client.DoRequest(ctx, "GET", "/api/v1/prism/export/"+id, nil, &export)
+		if err != nil {
+			return client.PrismExport{}, err
+		}
+		if export.Status == "success" || export.Status == "failed" {
+			return export, nil
+		}
+
+		tflog.Debug(ctx, "Waiting for prism export", map[string]interface{}{
+			"id":     id,
+			"status": export.Status,
+		})
+
+		select {
+		case <-ctx.Done():
+			return client.PrismExport{}, fmt.Errorf("status %q: %w", export.Status, ctx.Err())
+		case <-r.after(interval):
+		}
+	}
+}
+
+// parseExportCSV reads a CSV with a header line into one map per row, keyed
+// by column name.
+func parseExportCSV(r io.Reader) ([]map[string]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return []map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []map[string]string{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		row := make(map[string]string, len(header))
+		for i, column := range header {
+			if i < len(record) {
+				row[column] = record[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+}
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPrismExportComplete(t *testing.T) {
+	csvContent := "device__name,status\nMac-1,true\n\"Mac, 2\",false\n"
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(csvContent))
+	}))
+	defer storage.Close()
+
+	polls := 0
+	status := "success"
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/prism/export/exp-1" {
+			t.Errorf("Unexpected request %s", r.URL.Path)
+		}
+		polls++
+		if polls < 3 {
+			_, _ = w.Write([]byte(`{"id": "exp-1", "status": "processing"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": "exp-1", "status": "` + status + `", "signed_url": "` + storage.URL + `/export.csv"}`))
+	}))
+	defer api.Close()
+
+	immediate := func(time.Duration) <-chan time.Time {
+		ch := make(chan time.Time, 1)
+		ch <- time.Now()
+		return ch
+	}
+	r := &prismExportResource{client: client.NewClient(api.URL, "test-token"), after: immediate}
+
+	newModel := func(outputPath string) *prismExportResourceModel {
+		return &prismExportResourceModel{
+			ID:                types.StringValue("exp-1"),
+			Category:          types.StringValue("filevault"),
+			WaitForCompletion: types.BoolValue(true),
+			Timeout:           types.StringNull(),
+			PollInterval:      types.StringValue("1s"),
+			OutputPath:        types.StringValue(outputPath),
+			ParseCSV:          types.BoolValue(true),
+			Rows:              types.ListNull(types.MapType{ElemType: types.StringType}),
+		}
+	}
+
+	t.Run("waits, saves and parses the export", func(t *testing.T) {
+		polls = 0
+		outputPath := filepath.Join(t.TempDir(), "filevault.csv")
+		data := newModel(outputPath)
+
+		if diags := r.complete(context.Background(), data); diags.HasError() {
+			t.Fatalf("Expected no error, got %v", diags)
+		}
+		if polls != 3 || data.Status.ValueString() != "success" {
+			t.Errorf("Expected 3 polls and success, got %d polls and %s", polls, data.Status)
+		}
+
+		saved, err := os.ReadFile(outputPath)
+		if err != nil || string(saved) != csvContent {
+			t.Errorf("Expected the CSV to be saved, got %q, %v", saved, err)
+		}
+
+		var rows []map[string]string
+		data.Rows.ElementsAs(context.Background(), &rows, false)
+		if len(rows) != 2 || rows[1]["device__name"] != "Mac, 2" || rows[1]["status"] != "false" {
+			t.Errorf("Unexpected rows %v", rows)
+		}
+	})
+
+	t.Run("reports a failed export", func(t *testing.T) {
+		polls = 0
+		status = "failed"
+		defer func() { status = "success" }()
+
+		diags := r.complete(context.Background(), newModel(filepath.Join(t.TempDir(), "filevault.csv")))
+		if !diags.HasError() || diags[0].Summary() != "Export Failed" {
+			t.Fatalf("Expected an export failed error, got %v", diags)
+		}
+	})
+
+	t.Run("times out", func(t *testing.T) {
+		polls = -100
+		never := func(time.Duration) <-chan time.Time { return make(chan time.Time) }
+		r := &prismExportResource{client: client.NewClient(api.URL, "test-token"), after: never}
+		data := newModel(filepath.Join(t.TempDir(), "filevault.csv"))
+		data.Timeout = types.StringValue("50ms")
+
+		diags := r.complete(context.Background(), data)
+		if !diags.HasError() || diags[0].Summary() != "Export Timeout" {
+			t.Fatalf("Expected an export timeout error, got %v", diags)
+		}
+	})
+}
+
+func TestParseExportCSV(t *testing.T) {
+	rows, err := parseExportCSV(strings.NewReader(""))
+	if err != nil || len(rows) != 0 {
+		t.Errorf("Expected no rows for an empty export, got %v, %v", rows, err)
+	}
+
+	rows, err = parseExportCSV(strings.NewReader("a,b\n1\n"))
+	if err != nil || len(rows) != 1 || rows[0]["a"] != "1" {
+		t.Errorf("Expected short records to be read, got %v, %v", rows, err)
+	}
+	if _, ok := rows[0]["b"]; ok {
+		t.Errorf("Expected missing columns to be left out, got %v", rows[0])
+	}
+}
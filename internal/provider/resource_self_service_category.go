@@ -0,0 +1,237 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = &selfServiceCategoryResource{}
+var _ resource.ResourceWithImportState = &selfServiceCategoryResource{}
+var _ resource.ResourceWithIdentity = &selfServiceCategoryResource{}
+
+const selfServiceCategoriesEndpoint = "/api/v1/library/self-service/categories"
+
+func NewSelfServiceCategoryResource() resource.Resource {
+	return &selfServiceCategoryResource{}
+}
+
+type selfServiceCategoryResource struct {
+	client *client.Client
+}
+
+type selfServiceCategoryResourceModel struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+type selfServiceCategoryResourceIdentityModel struct {
+	ID types.String `tfsdk:"id"`
+}
+
+func (r *selfServiceCategoryResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_self_service_category"
+}
+
+func (r *selfServiceCategoryResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an Iru Self Service category. Custom Apps and Custom Scripts shown in Self Service reference a category through `self_service_category_id`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The unique identifier for the Self Service Category.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the Self Service Category.",
+			},
+		},
+	}
+}
+
+func (r *selfServiceCategoryResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The unique identifier for the Self Service Category.",
+			},
+		},
+	}
+}
+
+func (r *selfServiceCategoryResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *selfServiceCategoryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data selfServiceCategoryResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	categoryRequest := client.SelfServiceCategory{
+		Name: data.Name.ValueString(),
+	}
+
+	var categoryResponse client.SelfServiceCategory
+	err := r.client.DoRequest(ctx, "POST", selfServiceCategoriesEndpoint, categoryRequest, &categoryResponse)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create self service category, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue(categoryResponse.ID)
+	data.Name = types.StringValue(categoryResponse.Name)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	identity := selfServiceCategoryResourceIdentityModel{
+		ID: data.ID,
+	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, &identity)...)
+}
+
+func (r *selfServiceCategoryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data selfServiceCategoryResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var identity selfServiceCategoryResourceIdentityModel
+	resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := data.ID.ValueString()
+	if id == "" {
+		id = identity.ID.ValueString()
+	}
+
+	// Categories can only be listed, so the category is looked up in the list.
+	var categories []client.SelfServiceCategory
+	err := r.client.DoRequest(ctx, "GET", selfServiceCategoriesEndpoint, nil, &categories)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read self service category, got error: %s", err))
+		return
+	}
+
+	found := false
+	for _, category := range categories {
+		if category.ID == id {
+			data.ID = types.StringValue(category.ID)
+			data.Name = types.StringValue(category.Name)
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	identity.ID = data.ID
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, &identity)...)
+}
+
+func (r *selfServiceCategoryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data selfServiceCategoryResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	categoryRequest := client.SelfServiceCategory{
+		Name: data.Name.ValueString(),
+	}
+
+	var categoryResponse client.SelfServiceCategory
+	err := r.client.DoRequest(ctx, "PATCH", selfServiceCategoriesEndpoint+"/"+data.ID.ValueString(), categoryRequest, &categoryResponse)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update self service category, got error: %s", err))
+		return
+	}
+
+	data.Name = types.StringValue(categoryResponse.Name)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	identity := selfServiceCategoryResourceIdentityModel{
+		ID: data.ID,
+	}
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, &identity)...)
+}
+
+func (r *selfServiceCategoryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data selfServiceCategoryResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DoRequest(ctx, "DELETE", selfServiceCategoriesEndpoint+"/"+data.ID.ValueString(), nil, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete self service category, got error: %s", err))
+		return
+	}
+}
+
+func (r *selfServiceCategoryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// validateSelfServiceCategory reports an error when a library item is shown in
+// Self Service without a category. Unknown values, such as the ID of a
+// category created in the same apply, are accepted.
+func validateSelfServiceCategory(showInSelfService types.Bool, categoryID types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if showInSelfService.IsUnknown() || !showInSelfService.ValueBool() {
+		return diags
+	}
+	if categoryID.IsUnknown() {
+		return diags
+	}
+	if categoryID.IsNull() || categoryID.ValueString() == "" {
+		diags.AddAttributeError(
+			path.Root("self_service_category_id"),
+			"Missing Self Service Category",
+			"`self_service_category_id` must be set when `show_in_self_service` is `true`. Use the `id` of an `iru_self_service_category` resource or of an entry of the `iru_self_service_categories` data source.",
+		)
+	}
+	return diags
+}
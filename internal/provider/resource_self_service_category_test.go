@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSelfServiceCategoryResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: `
+resource "iru_self_service_category" "test" {
+  name = "tf-acc-test"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("iru_self_service_category.test", "name", "tf-acc-test"),
+					resource.TestCheckResourceAttrSet("iru_self_service_category.test", "id"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestValidateSelfServiceCategory(t *testing.T) {
+	tests := []struct {
+		name       string
+		show       types.Bool
+		categoryID types.String
+		wantError  bool
+	}{
+		{"hidden", types.BoolValue(false), types.StringNull(), false},
+		{"unset", types.BoolNull(), types.StringNull(), false},
+		{"missing category", types.BoolValue(true), types.StringNull(), true},
+		{"empty category", types.BoolValue(true), types.StringValue(""), true},
+		{"category", types.BoolValue(true), types.StringValue("cat-1"), false},
+		{"category not yet created", types.BoolValue(true), types.StringUnknown(), false},
+		{"unknown visibility", types.BoolUnknown(), types.StringNull(), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := validateSelfServiceCategory(tt.show, tt.categoryID)
+			if diags.HasError() != tt.wantError {
+				t.Errorf("validateSelfServiceCategory() errors = %v, want error %t", diags, tt.wantError)
+			}
+		})
+	}
+}
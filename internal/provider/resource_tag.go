@@ -193,5 +193,14 @@ func (r *tagResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 }
 
 func (r *tagResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, diags := resolveImportID(ctx, r.client, "Tag", "/api/v1/tags", req.ID, func(t client.Tag) (string, string) {
+		return t.ID, t.Name
+	})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	req.ID = id
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"strings"
+	"unicode"
+)
+
+// compareVersions orders application version strings such as `1.10.2`,
+// `2.0b3` and Apple's `14.2.1 (23C71)`. Versions are compared segment by
+// segment: digits numerically, letters alphabetically ignoring case, and a
+// letter segment sorts before a missing or numeric one, so `2.0b3` comes
+// before `2.0`. A build number in parentheses only breaks ties.
+func compareVersions(a, b string) int {
+	aVersion, aBuild := splitVersionBuild(a)
+	bVersion, bBuild := splitVersionBuild(b)
+	if c := compareVersionSegments(versionSegments(aVersion), versionSegments(bVersion)); c != 0 {
+		return c
+	}
+	return compareVersionSegments(versionSegments(aBuild), versionSegments(bBuild))
+}
+
+// splitVersionBuild splits `14.2.1 (23C71)` into `14.2.1` and `23C71`.
+func splitVersionBuild(v string) (version, build string) {
+	v = strings.TrimSpace(v)
+	open := strings.Index(v, "(")
+	if open < 0 || !strings.HasSuffix(v, ")") {
+		return v, ""
+	}
+	return strings.TrimSpace(v[:open]), v[open+1 : len(v)-1]
+}
+
+// versionSegments splits a version into runs of digits and runs of letters,
+// dropping separators.
+func versionSegments(v string) []string {
+	var segments []string
+	start := -1
+	for i, r := range v {
+		if start >= 0 && (!isVersionRune(r) || unicode.IsDigit(r) != unicode.IsDigit(rune(v[start]))) {
+			segments = append(segments, v[start:i])
+			start = -1
+		}
+		if start < 0 && isVersionRune(r) {
+			start = i
+		}
+	}
+	if start >= 0 {
+		segments = append(segments, v[start:])
+	}
+	return segments
+}
+
+func isVersionRune(r rune) bool {
+	return r < unicode.MaxASCII && (unicode.IsDigit(r) || unicode.IsLetter(r))
+}
+
+func compareVersionSegments(a, b []string) int {
+	for i := 0; i < max(len(a), len(b)); i++ {
+		var x, y string
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if c := compareVersionSegment(x, y); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// compareVersionSegment compares two segments. A missing segment counts as
+// zero.
+func compareVersionSegment(x, y string) int {
+	if x == "" {
+		x = "0"
+	}
+	if y == "" {
+		y = "0"
+	}
+
+	xNumeric, yNumeric := unicode.IsDigit(rune(x[0])), unicode.IsDigit(rune(y[0]))
+	switch {
+	case xNumeric && yNumeric:
+		// Compare without parsing so that long build numbers cannot overflow.
+		x, y = strings.TrimLeft(x, "0"), strings.TrimLeft(y, "0")
+		if len(x) != len(y) {
+			if len(x) < len(y) {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(x, y)
+	case xNumeric:
+		return 1
+	case yNumeric:
+		return -1
+	default:
+		return strings.Compare(strings.ToLower(x), strings.ToLower(y))
+	}
+}
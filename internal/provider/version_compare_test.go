@@ -0,0 +1,33 @@
+package provider
+
+import "testing"
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.10.0", "1.9.9", 1},
+		{"1.2", "1.2.0", 0},
+		{"120.0.6099.129", "120.0.6099.71", 1},
+		{"2.0b3", "2.0", -1},
+		{"2.0b3", "2.0b10", -1},
+		{"2.0-beta", "2.0-alpha", 1},
+		{"14.2.1 (23C71)", "14.2.1 (23C64)", 1},
+		{"14.2.1 (23C71)", "14.2.1", 1},
+		{"14.2.1 (23C71)", "14.3", -1},
+		{"13.6.3 (22G436)", "13.6.10 (22H123)", -1},
+		{"v1.0", "V1.0", 0},
+		{"1.0.0000000000000000000001", "1.0.1", 0},
+		{"", "0", 0},
+	}
+
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d; want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := compareVersions(tt.b, tt.a); got != -tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d; want %d", tt.b, tt.a, got, -tt.want)
+		}
+	}
+}
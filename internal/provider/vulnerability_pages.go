@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+)
+
+// vulnerabilityPageSize is the number of rows requested per page from the
+// Vulnerability Management list endpoints.
+const vulnerabilityPageSize = 50
+
+type vulnerabilityPage[T any] struct {
+	Results []T    `json:"results"`
+	Next    string `json:"next"`
+	Count   int    `json:"count"`
+}
+
+// listVulnerabilityPages collects every row of a Vulnerability Management list
+// endpoint. The first page reports the total count, so the remaining pages are
+// requested concurrently; when the count is missing, pages are followed one at
+// a time instead.
+func listVulnerabilityPages[T any](ctx context.Context, c *client.Client, endpoint string) ([]T, error) {
+	getPage := func(page int) (vulnerabilityPage[T], error) {
+		var resp vulnerabilityPage[T]
+		err := c.DoRequest(ctx, "GET", fmt.Sprintf("%s?size=%d&page=%d", endpoint, vulnerabilityPageSize, page), nil, &resp)
+		return resp, err
+	}
+
+	first, err := getPage(1)
+	if err != nil {
+		return nil, err
+	}
+	all := first.Results
+
+	if first.Count == 0 {
+		for page, resp := 2, first; resp.Next != "" && len(resp.Results) >= vulnerabilityPageSize; page++ {
+			if resp, err = getPage(page); err != nil {
+				return nil, err
+			}
+			all = append(all, resp.Results...)
+		}
+		return all, nil
+	}
+
+	remaining := (first.Count+vulnerabilityPageSize-1)/vulnerabilityPageSize - 1
+	pages := make([][]T, remaining)
+	errs := make([]error, remaining)
+	forEachConcurrently(defaultConcurrency, remaining, func(i int) {
+		resp, err := getPage(i + 2)
+		pages[i], errs[i] = resp.Results, err
+	})
+	for i := range pages {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		all = append(all, pages[i]...)
+	}
+	return all, nil
+}
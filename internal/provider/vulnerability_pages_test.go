@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/MScottBlake/terraform-provider-iru/internal/client"
+)
+
+// vulnerabilityPagesServer serves total rows in pages of vulnerabilityPageSize,
+// reporting the count when withCount is set.
+func vulnerabilityPagesServer(t *testing.T, total int, withCount bool, requests *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if size := r.URL.Query().Get("size"); size != strconv.Itoa(vulnerabilityPageSize) {
+			t.Errorf("Expected size %d, got %s", vulnerabilityPageSize, size)
+		}
+
+		var rows []string
+		for i := (page - 1) * vulnerabilityPageSize; i < page*vulnerabilityPageSize && i < total; i++ {
+			rows = append(rows, fmt.Sprintf(`{"cve_id": "CVE-%d"}`, i))
+		}
+		next := ""
+		if page*vulnerabilityPageSize < total {
+			next = "more"
+		}
+		count := ""
+		if withCount {
+			count = fmt.Sprintf(`, "count": %d`, total)
+		}
+		_, _ = fmt.Fprintf(w, `{"results": [%s], "next": %q%s}`, strings.Join(rows, ","), next, count)
+	}))
+}
+
+func TestListVulnerabilityPages(t *testing.T) {
+	for _, withCount := range []bool{true, false} {
+		var requests atomic.Int32
+		server := vulnerabilityPagesServer(t, 2*vulnerabilityPageSize+7, withCount, &requests)
+
+		all, err := listVulnerabilityPages[client.Vulnerability](context.Background(), client.NewClient(server.URL, "test-token"), "/api/v1/vulnerability-management/vulnerabilities")
+		server.Close()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(all) != 2*vulnerabilityPageSize+7 || requests.Load() != 3 {
+			t.Errorf("Expected %d rows in 3 requests, got %d in %d (count reported: %t)", 2*vulnerabilityPageSize+7, len(all), requests.Load(), withCount)
+		}
+		for i, v := range all {
+			if v.CVEID != fmt.Sprintf("CVE-%d", i) {
+				t.Fatalf("Expected rows in page order, got %s at %d (count reported: %t)", v.CVEID, i, withCount)
+			}
+		}
+	}
+}